@@ -150,6 +150,110 @@ func (d *DeepNum) Exp() *DeepNum {
 	}
 }
 
+func (d *DeepNum) Tan() *DeepNum {
+	res := &DeepNum{Value: math.Tan(d.Value)}
+	if d.Deriv != nil {
+		tan := d.removeOneDerivative().Tan()
+		res.Deriv = d.Deriv.Mul(tan.Mul(tan).AddScaler(1))
+	}
+	return res
+}
+
+func (d *DeepNum) Atan() *DeepNum {
+	res := &DeepNum{Value: math.Atan(d.Value)}
+	if d.Deriv != nil {
+		lessD := d.removeOneDerivative()
+		res.Deriv = d.Deriv.Div(lessD.Mul(lessD).AddScaler(1))
+	}
+	return res
+}
+
+func (d *DeepNum) Asin() *DeepNum {
+	res := &DeepNum{Value: math.Asin(d.Value)}
+	if d.Deriv != nil {
+		res.Deriv = d.Deriv.Div(d.removeOneDerivative().complementSqrt())
+	}
+	return res
+}
+
+func (d *DeepNum) Acos() *DeepNum {
+	res := &DeepNum{Value: math.Acos(d.Value)}
+	if d.Deriv != nil {
+		res.Deriv = d.Deriv.Div(d.removeOneDerivative().complementSqrt()).MulScaler(-1)
+	}
+	return res
+}
+
+func (d *DeepNum) Sinh() *DeepNum {
+	res := &DeepNum{Value: math.Sinh(d.Value)}
+	if d.Deriv != nil {
+		res.Deriv = d.Deriv.Mul(d.removeOneDerivative().Cosh())
+	}
+	return res
+}
+
+func (d *DeepNum) Cosh() *DeepNum {
+	res := &DeepNum{Value: math.Cosh(d.Value)}
+	if d.Deriv != nil {
+		res.Deriv = d.Deriv.Mul(d.removeOneDerivative().Sinh())
+	}
+	return res
+}
+
+func (d *DeepNum) Tanh() *DeepNum {
+	res := &DeepNum{Value: math.Tanh(d.Value)}
+	if d.Deriv != nil {
+		tanh := d.removeOneDerivative().Tanh()
+		res.Deriv = d.Deriv.Mul(tanh.Mul(tanh).MulScaler(-1).AddScaler(1))
+	}
+	return res
+}
+
+func (d *DeepNum) Erf() *DeepNum {
+	res := &DeepNum{Value: math.Erf(d.Value)}
+	if d.Deriv != nil {
+		lessD := d.removeOneDerivative()
+		gaussian := lessD.Mul(lessD).MulScaler(-1).Exp()
+		res.Deriv = d.Deriv.Mul(gaussian.MulScaler(2 / math.Sqrt(math.Pi)))
+	}
+	return res
+}
+
+// Abs computes the absolute value of d.
+// At 0, every derivative is taken to be 0, which is
+// a subgradient for the first derivative.
+func (d *DeepNum) Abs() *DeepNum {
+	res := d.MulScaler(sign(d.Value))
+	res.Value = math.Abs(d.Value)
+	return res
+}
+
+// Max computes the maximum of d and d1.
+// If they are equal, the derivatives are averaged,
+// giving a subgradient for the first derivative.
+func (d *DeepNum) Max(d1 *DeepNum) *DeepNum {
+	if d.Value > d1.Value {
+		return d
+	} else if d.Value < d1.Value {
+		return d1
+	}
+	res := d.Add(d1).MulScaler(0.5)
+	res.Value = d.Value
+	return res
+}
+
+// Min is like Max, but for the minimum.
+func (d *DeepNum) Min(d1 *DeepNum) *DeepNum {
+	if d.Value < d1.Value {
+		return d
+	} else if d.Value > d1.Value {
+		return d1
+	}
+	res := d.Add(d1).MulScaler(0.5)
+	res.Value = d.Value
+	return res
+}
+
 func (d *DeepNum) PowScaler(c float64) *DeepNum {
 	if c == 0 {
 		return NewDeepNum(1, d.Depth())
@@ -183,3 +287,8 @@ func (d *DeepNum) removeOneDerivative() *DeepNum {
 	}
 	return res
 }
+
+// complementSqrt computes sqrt(1-d^2).
+func (d *DeepNum) complementSqrt() *DeepNum {
+	return d.Mul(d).MulScaler(-1).AddScaler(1).Sqrt()
+}
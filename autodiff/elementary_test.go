@@ -0,0 +1,127 @@
+package autodiff
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type elementaryFunc struct {
+	name string
+	num  func(n Num) Num
+	deep func(d *DeepNum) *DeepNum
+	min  float64
+	max  float64
+}
+
+var elementaryFuncs = []elementaryFunc{
+	{"Log", Num.Log, (*DeepNum).Log, 0.1, 10},
+	{"Tan", Num.Tan, (*DeepNum).Tan, -1.4, 1.4},
+	{"Atan", Num.Atan, (*DeepNum).Atan, -5, 5},
+	{"Asin", Num.Asin, (*DeepNum).Asin, -0.95, 0.95},
+	{"Acos", Num.Acos, (*DeepNum).Acos, -0.95, 0.95},
+	{"Sinh", Num.Sinh, (*DeepNum).Sinh, -3, 3},
+	{"Cosh", Num.Cosh, (*DeepNum).Cosh, -3, 3},
+	{"Tanh", Num.Tanh, (*DeepNum).Tanh, -3, 3},
+	{"Erf", Num.Erf, (*DeepNum).Erf, -3, 3},
+	{"Abs", Num.Abs, (*DeepNum).Abs, -3, 3},
+	{
+		"Max",
+		func(n Num) Num {
+			return n.Max(n.Mul(n).Sub(NewNum(1, len(n.Gradient))))
+		},
+		func(d *DeepNum) *DeepNum {
+			return d.Max(d.Mul(d).AddScaler(-1))
+		},
+		-3, 3,
+	},
+	{
+		"Min",
+		func(n Num) Num {
+			return n.Sin().Min(n.Cos())
+		},
+		func(d *DeepNum) *DeepNum {
+			return d.Sin().Min(d.Cos())
+		},
+		-3, 3,
+	},
+}
+
+func TestElementaryNumFiniteDiff(t *testing.T) {
+	const h = 1e-6
+	for _, f := range elementaryFuncs {
+		for i := 0; i < 50; i++ {
+			x := f.min + rand.Float64()*(f.max-f.min)
+			actual := f.num(NewNumVar(x, 1, 0))
+			if expected := f.num(NewNum(x, 1)).Value; actual.Value != expected {
+				t.Errorf("%s(%f): inconsistent value", f.name, x)
+			}
+			approx := (f.num(NewNum(x+h, 1)).Value - f.num(NewNum(x-h, 1)).Value) / (2 * h)
+			if !derivativesClose(actual.Gradient[0], approx) {
+				t.Errorf("%s'(%f): expected %f but got %f", f.name, x, approx,
+					actual.Gradient[0])
+			}
+		}
+	}
+}
+
+func TestElementaryDeepNumFiniteDiff(t *testing.T) {
+	const h = 1e-5
+	for _, f := range elementaryFuncs {
+		for i := 0; i < 50; i++ {
+			x := f.min + rand.Float64()*(f.max-f.min)
+			actual := f.deep(NewDeepNumVar(x, 3))
+			num := f.num(NewNumVar(x, 1, 0))
+			if actual.Value != num.Value || !derivativesClose(actual.Deriv.Value, num.Gradient[0]) {
+				t.Errorf("%s(%f): DeepNum does not match Num", f.name, x)
+			}
+
+			// Compare each derivative to a finite difference
+			// of the one before it.
+			for depth := 1; depth <= 3; depth++ {
+				upper := nthDeriv(f.deep(NewDeepNumVar(x+h, depth-1)), depth-1)
+				lower := nthDeriv(f.deep(NewDeepNumVar(x-h, depth-1)), depth-1)
+				approx := (upper - lower) / (2 * h)
+				if exact := nthDeriv(actual, depth); !derivativesClose(exact, approx) {
+					t.Errorf("%s derivative %d at %f: expected %f but got %f",
+						f.name, depth, x, approx, exact)
+				}
+			}
+		}
+	}
+}
+
+func TestElementaryKinks(t *testing.T) {
+	x := NewNumVar(0, 1, 0)
+	if abs := x.Abs(); abs.Value != 0 || abs.Gradient[0] != 0 {
+		t.Error("bad Abs at 0:", abs)
+	}
+	y := NewNumVar(0, 1, 0).Mul(NewNum(3, 1))
+	if max := x.Max(y); max.Value != 0 || max.Gradient[0] != 2 {
+		t.Error("bad Max at kink:", max)
+	}
+	if min := x.Min(y); min.Value != 0 || min.Gradient[0] != 2 {
+		t.Error("bad Min at kink:", min)
+	}
+
+	d := NewDeepNumVar(0, 2)
+	if abs := d.Abs(); abs.Value != 0 || abs.Deriv.Value != 0 || abs.Deriv.Deriv.Value != 0 {
+		t.Error("bad DeepNum Abs at 0")
+	}
+	d1 := d.MulScaler(3).AddScaler(0)
+	max := d.Max(d1)
+	if max.Value != 0 || max.Deriv.Value != 2 {
+		t.Error("bad DeepNum Max at kink:", max.Value, max.Deriv.Value)
+	}
+}
+
+func nthDeriv(d *DeepNum, n int) float64 {
+	for i := 0; i < n; i++ {
+		d = d.Deriv
+	}
+	return d.Value
+}
+
+func derivativesClose(actual, expected float64) bool {
+	return math.Abs(actual-expected) <= 1e-4*math.Max(1, math.Abs(expected))
+}
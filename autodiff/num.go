@@ -95,6 +95,72 @@ func (n Num) Exp() Num {
 	return n.chainRule(exp, exp)
 }
 
+func (n Num) Log() Num {
+	return n.chainRule(math.Log(n.Value), 1/n.Value)
+}
+
+func (n Num) Tan() Num {
+	tan := math.Tan(n.Value)
+	return n.chainRule(tan, 1+tan*tan)
+}
+
+func (n Num) Atan() Num {
+	return n.chainRule(math.Atan(n.Value), 1/(1+n.Value*n.Value))
+}
+
+func (n Num) Asin() Num {
+	return n.chainRule(math.Asin(n.Value), 1/math.Sqrt(1-n.Value*n.Value))
+}
+
+func (n Num) Acos() Num {
+	return n.chainRule(math.Acos(n.Value), -1/math.Sqrt(1-n.Value*n.Value))
+}
+
+func (n Num) Sinh() Num {
+	return n.chainRule(math.Sinh(n.Value), math.Cosh(n.Value))
+}
+
+func (n Num) Cosh() Num {
+	return n.chainRule(math.Cosh(n.Value), math.Sinh(n.Value))
+}
+
+func (n Num) Tanh() Num {
+	tanh := math.Tanh(n.Value)
+	return n.chainRule(tanh, 1-tanh*tanh)
+}
+
+func (n Num) Erf() Num {
+	return n.chainRule(math.Erf(n.Value), 2/math.Sqrt(math.Pi)*math.Exp(-n.Value*n.Value))
+}
+
+// Abs computes the absolute value of n.
+// At 0, the subgradient 0 is used.
+func (n Num) Abs() Num {
+	return n.chainRule(math.Abs(n.Value), sign(n.Value))
+}
+
+// Max computes the maximum of n and n1.
+// If they are equal, the gradient is the average of
+// their gradients, which is a valid subgradient.
+func (n Num) Max(n1 Num) Num {
+	if n.Value > n1.Value {
+		return n
+	} else if n.Value < n1.Value {
+		return n1
+	}
+	return n.Add(n1).chainRule(n.Value, 0.5)
+}
+
+// Min is like Max, but for the minimum.
+func (n Num) Min(n1 Num) Num {
+	if n.Value < n1.Value {
+		return n
+	} else if n.Value > n1.Value {
+		return n1
+	}
+	return n.Add(n1).chainRule(n.Value, 0.5)
+}
+
 func (n Num) PowScaler(c float64) Num {
 	if c == 0 {
 		return NewNum(1, len(n.Gradient))
@@ -109,3 +175,12 @@ func (n Num) chainRule(newVal, opDerivative float64) Num {
 	}
 	return res
 }
+
+func sign(x float64) float64 {
+	if x > 0 {
+		return 1
+	} else if x < 0 {
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package conjgrad
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// BiCGSTAB solves a system of linear equations t*x = b
+// for x using the stabilized bi-conjugate gradient
+// method, where t is any invertible linear operator.
+//
+// Unlike GMRES, BiCGSTAB uses a constant amount of
+// memory, but its residuals do not decrease
+// monotonically.
+//
+// The preconditioner in opts is an approximation of
+// the inverse of t which is applied on the right.
+//
+// If the method breaks down and a restart does not
+// help, or if the residual does not improve for
+// opts.StagnationLimit iterations, the solve stops
+// with the reason Stagnated.
+// In every case, the best solution found is returned.
+func BiCGSTAB(t LinTran, b linalg.Vector, opts *Options) *Result {
+	if opts == nil {
+		opts = &Options{}
+	}
+	precond := opts.precond()
+	result := &Result{}
+
+	solution := make(linalg.Vector, t.Dim())
+	residual := b.Copy()
+
+	best := solution.Copy()
+	bestResidual := residual.MaxAbs()
+	sinceBest := 0
+	stagnationLimit := opts.stagnationLimit(t.Dim())
+
+	var shadow, direction, v linalg.Vector
+	var rho, alpha, omega float64
+	restart := func() {
+		shadow = residual.Copy()
+		direction = make(linalg.Vector, len(residual))
+		v = make(linalg.Vector, len(residual))
+		rho, alpha, omega = 1, 1, 1
+	}
+	restart()
+	justRestarted := true
+
+	for i := 1; bestResidual > opts.Prec; i++ {
+		newRho := shadow.Dot(residual)
+		if newRho == 0 || omega == 0 {
+			if justRestarted {
+				result.Reason = Stagnated
+				break
+			}
+			restart()
+			justRestarted = true
+			continue
+		}
+		beta := (newRho / rho) * (alpha / omega)
+		rho = newRho
+		direction = direction.Add(v.Scale(-omega)).Scale(beta).Add(residual)
+
+		precDirection := precond.Apply(direction)
+		v = t.Apply(precDirection)
+		alpha = rho / shadow.Dot(v)
+		s := residual.Copy().Add(v.Copy().Scale(-alpha))
+		solution.Add(precDirection.Copy().Scale(alpha))
+
+		precS := precond.Apply(s)
+		st := t.Apply(precS)
+		stDot := st.Dot(st)
+		if stDot == 0 {
+			omega = 0
+		} else {
+			omega = st.Dot(s) / stDot
+		}
+		solution.Add(precS.Copy().Scale(omega))
+
+		if i%residualUpdateFrequency == 0 {
+			residual = t.Apply(solution).Scale(-1).Add(b)
+		} else {
+			residual = s.Add(st.Scale(-omega))
+		}
+		justRestarted = false
+
+		maxRes := residual.MaxAbs()
+		if maxRes < bestResidual {
+			bestResidual = maxRes
+			best = solution.Copy()
+			sinceBest = 0
+		} else {
+			sinceBest++
+		}
+		if result.step(opts, maxRes) {
+			break
+		}
+		if math.IsNaN(maxRes) || (stagnationLimit >= 0 && sinceBest > stagnationLimit) {
+			result.Reason = Stagnated
+			break
+		}
+	}
+
+	return result.finish(t, b, best)
+}
+
+// BiCGSTABStoppable is like BiCGSTAB, but it takes
+// its options as arguments and only returns the
+// solution.
+//
+// If precond is nil, then no preconditioning is used.
+// The prec and cancelChan arguments behave just like
+// they do for SolveStoppable.
+func BiCGSTABStoppable(t, precond LinTran, b linalg.Vector, prec float64,
+	cancelChan <-chan struct{}) linalg.Vector {
+	opts := &Options{Precond: precond, Prec: prec, Cancel: cancelChan}
+	return BiCGSTAB(t, b, opts).Solution
+}
+
+// BiCGSTABPrec is like BiCGSTABStoppable, but it
+// does not give you the option to cancel the solve
+// early.
+func BiCGSTABPrec(t, precond LinTran, b linalg.Vector, prec float64) linalg.Vector {
+	return BiCGSTABStoppable(t, precond, b, prec, nil)
+}
+
+// stagnationIterations returns the number of
+// iterations without any improvement after which an
+// iterative solver for an n-dimensional system gives
+// up.
+func stagnationIterations(n int) int {
+	if n < 50 {
+		return 100
+	}
+	return 2 * n
+}
@@ -0,0 +1,40 @@
+package conjgrad
+
+import (
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestBiCGSTAB(t *testing.T) {
+	op := convectionDiffusion{size: 100, wind: 0.4}
+	b := randomVector(100)
+	solution := BiCGSTABPrec(op, nil, b, 1e-10)
+	verifySolution(t, op, solution, b, 1e-10)
+}
+
+func TestBiCGSTABPrecond(t *testing.T) {
+	op := convectionDiffusion{size: 100, wind: 0.4}
+	b := randomVector(100)
+	solution := BiCGSTABPrec(op, diagonalInverse{2.4}, b, 1e-10)
+	verifySolution(t, op, solution, b, 1e-10)
+}
+
+func TestBiCGSTABSingular(t *testing.T) {
+	// The solver should give up rather than loop forever.
+	op := MatLinTran{M: randomSingular()}
+	BiCGSTABPrec(op, nil, randomVector(3), 1e-10)
+}
+
+func randomSingular() *linalg.Matrix {
+	res := &linalg.Matrix{
+		Rows: 3,
+		Cols: 3,
+		Data: []float64{
+			1, 2, 3,
+			4, 5, 6,
+			7, 8, 9,
+		},
+	}
+	return res
+}
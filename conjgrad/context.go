@@ -0,0 +1,52 @@
+package conjgrad
+
+import (
+	"context"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// SolveContext is like Solve, but it stops early if
+// ctx is done.
+//
+// If the solve is stopped by ctx, the partial result
+// is returned along with ctx.Err().
+func SolveContext(ctx context.Context, t LinTran, b linalg.Vector,
+	opts *Options) (*Result, error) {
+	opts = contextOptions(ctx, opts)
+	return contextResult(ctx, Solve(t, b, opts))
+}
+
+// GMRESContext is like GMRES, but it stops early if
+// ctx is done, just like SolveContext.
+func GMRESContext(ctx context.Context, t LinTran, b linalg.Vector, restart int,
+	opts *Options) (*Result, error) {
+	opts = contextOptions(ctx, opts)
+	return contextResult(ctx, GMRES(t, b, restart, opts))
+}
+
+// BiCGSTABContext is like BiCGSTAB, but it stops early
+// if ctx is done, just like SolveContext.
+func BiCGSTABContext(ctx context.Context, t LinTran, b linalg.Vector,
+	opts *Options) (*Result, error) {
+	opts = contextOptions(ctx, opts)
+	return contextResult(ctx, BiCGSTAB(t, b, opts))
+}
+
+// contextOptions copies opts so that the solve also
+// stops when ctx is done.
+func contextOptions(ctx context.Context, opts *Options) *Options {
+	var res Options
+	if opts != nil {
+		res = *opts
+	}
+	res.done = ctx.Done()
+	return &res
+}
+
+func contextResult(ctx context.Context, r *Result) (*Result, error) {
+	if r.Reason == Cancelled {
+		return r, ctx.Err()
+	}
+	return r, nil
+}
@@ -0,0 +1,56 @@
+package conjgrad
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolveContext(t *testing.T) {
+	problem := shiftedLaplacian(10)
+	b := randomVector(problem.Dim())
+
+	result, err := SolveContext(context.Background(), problem, b, &Options{Prec: 1e-8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifySolution(t, problem, result.Solution, b, 1e-8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for name, solver := range map[string]func() (*Result, error){
+		"CG": func() (*Result, error) {
+			return SolveContext(ctx, problem, b, &Options{Prec: 1e-8})
+		},
+		"GMRES": func() (*Result, error) {
+			return GMRESContext(ctx, problem, b, 0, &Options{Prec: 1e-8})
+		},
+		"BiCGSTAB": func() (*Result, error) {
+			return BiCGSTABContext(ctx, problem, b, nil)
+		},
+	} {
+		result, err := solver()
+		if err != context.Canceled {
+			t.Errorf("%s: expected Canceled but got %v", name, err)
+		}
+		if result.Reason != Cancelled || result.Iterations != 1 {
+			t.Errorf("%s: unexpected result: %s after %d iterations", name,
+				result.Reason, result.Iterations)
+		}
+	}
+}
+
+func TestSolveContextCancelChan(t *testing.T) {
+	problem := shiftedLaplacian(10)
+	b := randomVector(problem.Dim())
+
+	cancelChan := make(chan struct{})
+	close(cancelChan)
+	result, err := SolveContext(context.Background(), problem, b,
+		&Options{Prec: 1e-8, Cancel: cancelChan})
+	if err != nil {
+		t.Error("unexpected error:", err)
+	}
+	if result.Reason != Cancelled {
+		t.Error("unexpected reason:", result.Reason)
+	}
+}
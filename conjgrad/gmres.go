@@ -0,0 +1,145 @@
+package conjgrad
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// DefaultRestart is a reasonable number of GMRES
+// iterations to run between restarts.
+const DefaultRestart = 30
+
+// GMRES solves a system of linear equations t*x = b
+// for x using restarted GMRES, where t is any
+// invertible linear operator.
+//
+// The restart argument specifies how many iterations
+// to run before restarting, which bounds the amount of
+// memory used by the solver.
+// If restart is not positive, DefaultRestart is used.
+//
+// The preconditioner in opts is an approximation of
+// the inverse of t which is applied on the right, so
+// that the residuals GMRES minimizes are those of the
+// original system.
+//
+// If a restart cycle fails to reduce the residual at
+// all, the solve stops with the reason Stagnated.
+func GMRES(t LinTran, b linalg.Vector, restart int, opts *Options) *Result {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if restart <= 0 {
+		restart = DefaultRestart
+	}
+	result := &Result{}
+
+	solution := make(linalg.Vector, t.Dim())
+	residual := b.Copy()
+	lastNorm := math.Inf(1)
+
+	for residual.MaxAbs() > opts.Prec {
+		norm := residual.Mag()
+		if !(norm < lastNorm) {
+			result.Reason = Stagnated
+			break
+		}
+		lastNorm = norm
+		update, stopped := gmresCycle(t, residual, norm, restart, opts, result)
+		solution.Add(update)
+		residual = t.Apply(solution).Scale(-1).Add(b)
+		if stopped {
+			break
+		}
+	}
+
+	return result.finish(t, b, solution)
+}
+
+// GMRESStoppable is like GMRES, but it takes its
+// options as arguments and only returns the solution.
+//
+// If precond is nil, then no preconditioning is used.
+// The prec and cancelChan arguments behave just like
+// they do for SolveStoppable.
+func GMRESStoppable(t, precond LinTran, b linalg.Vector, restart int, prec float64,
+	cancelChan <-chan struct{}) linalg.Vector {
+	opts := &Options{Precond: precond, Prec: prec, Cancel: cancelChan}
+	return GMRES(t, b, restart, opts).Solution
+}
+
+// GMRESPrec is like GMRESStoppable, but it does not
+// give you the option to cancel the solve early.
+func GMRESPrec(t, precond LinTran, b linalg.Vector, restart int,
+	prec float64) linalg.Vector {
+	return GMRESStoppable(t, precond, b, restart, prec, nil)
+}
+
+// gmresCycle runs up to restart iterations of GMRES
+// on the system t*x = residual, starting at x = 0.
+// It returns x and whether or not the solve should
+// stop early.
+func gmresCycle(t LinTran, residual linalg.Vector, norm float64, restart int,
+	opts *Options, result *Result) (linalg.Vector, bool) {
+	precond := opts.precond()
+	basis := []linalg.Vector{residual.Copy().Scale(1 / norm)}
+	hessenberg := make([]linalg.Vector, 0, restart)
+	cosines := make([]float64, 0, restart)
+	sines := make([]float64, 0, restart)
+	rhs := linalg.Vector{norm}
+
+	var stopped bool
+	for j := 0; j < restart; j++ {
+		w := t.Apply(precond.Apply(basis[j]))
+
+		column := make(linalg.Vector, j+2)
+		for i, v := range basis {
+			column[i] = w.Dot(v)
+			w.Add(v.Copy().Scale(-column[i]))
+		}
+		column[j+1] = w.Mag()
+		nextNorm := column[j+1]
+
+		for i := range cosines {
+			column[i], column[i+1] = cosines[i]*column[i]+sines[i]*column[i+1],
+				-sines[i]*column[i]+cosines[i]*column[i+1]
+		}
+		hyp := math.Hypot(column[j], column[j+1])
+		if hyp == 0 {
+			// The operator is singular on the Krylov subspace.
+			break
+		}
+		c, s := column[j]/hyp, column[j+1]/hyp
+		column[j] = hyp
+		column[j+1] = 0
+		cosines = append(cosines, c)
+		sines = append(sines, s)
+		rhs = append(rhs, -s*rhs[j])
+		rhs[j] *= c
+		hessenberg = append(hessenberg, column)
+
+		residualNorm := math.Abs(rhs[j+1])
+		stopped = result.step(opts, residualNorm)
+		if stopped || nextNorm == 0 || residualNorm <= opts.Prec {
+			break
+		}
+		basis = append(basis, w.Scale(1/nextNorm))
+	}
+
+	// Solve the upper-triangular least-squares system.
+	coeffs := make(linalg.Vector, len(hessenberg))
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		sum := rhs[i]
+		for k := i + 1; k < len(coeffs); k++ {
+			sum -= hessenberg[k][i] * coeffs[k]
+		}
+		coeffs[i] = sum / hessenberg[i][i]
+	}
+
+	combination := make(linalg.Vector, len(residual))
+	for i, c := range coeffs {
+		combination.Add(basis[i].Copy().Scale(c))
+	}
+	return precond.Apply(combination), stopped
+}
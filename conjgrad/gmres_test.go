@@ -0,0 +1,90 @@
+package conjgrad
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestGMRES(t *testing.T) {
+	op := convectionDiffusion{size: 100, wind: 0.4}
+	b := randomVector(100)
+	for _, restart := range []int{5, 30, 200} {
+		solution := GMRESPrec(op, nil, b, restart, 1e-10)
+		verifySolution(t, op, solution, b, 1e-10)
+	}
+}
+
+func TestGMRESPrecond(t *testing.T) {
+	op := convectionDiffusion{size: 100, wind: 0.4}
+	b := randomVector(100)
+	precond := diagonalInverse{2 + 0.4}
+	solution := GMRESPrec(op, precond, b, 10, 1e-10)
+	verifySolution(t, op, solution, b, 1e-10)
+}
+
+func TestGMRESCancel(t *testing.T) {
+	op := convectionDiffusion{size: 100, wind: 0.4}
+	cancel := make(chan struct{})
+	close(cancel)
+	solution := GMRESStoppable(op, nil, randomVector(100), 10, 1e-10, cancel)
+	if len(solution) != 100 {
+		t.Error("unexpected solution length:", len(solution))
+	}
+}
+
+// convectionDiffusion is a nonsymmetric LinTran
+// arising from a discretized 1D convection-diffusion
+// equation.
+type convectionDiffusion struct {
+	size int
+	wind float64
+}
+
+func (c convectionDiffusion) Dim() int {
+	return c.size
+}
+
+func (c convectionDiffusion) Apply(v linalg.Vector) linalg.Vector {
+	res := make(linalg.Vector, len(v))
+	for i, x := range v {
+		res[i] = (2 + c.wind) * x
+		if i > 0 {
+			res[i] -= (1 + c.wind) * v[i-1]
+		}
+		if i+1 < len(v) {
+			res[i] -= v[i+1]
+		}
+	}
+	return res
+}
+
+// diagonalInverse is a LinTran which divides
+// every component by a constant.
+type diagonalInverse struct {
+	diag float64
+}
+
+func (d diagonalInverse) Dim() int {
+	return 0
+}
+
+func (d diagonalInverse) Apply(v linalg.Vector) linalg.Vector {
+	return v.Copy().Scale(1 / d.diag)
+}
+
+func verifySolution(t *testing.T, op LinTran, solution, b linalg.Vector, prec float64) {
+	residual := op.Apply(solution).Scale(-1).Add(b)
+	if res := residual.MaxAbs(); res > prec {
+		t.Error("residual too large:", res)
+	}
+}
+
+func randomVector(size int) linalg.Vector {
+	res := make(linalg.Vector, size)
+	for i := range res {
+		res[i] = rand.Float64()*2 - 1
+	}
+	return res
+}
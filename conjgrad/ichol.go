@@ -0,0 +1,111 @@
+package conjgrad
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+var ErrIncompleteBreakdown = errors.New("incomplete factorization broke down")
+
+// IncompleteCholesky is an IC(0) preconditioner for a
+// sparse symmetric positive-definite matrix A.
+//
+// It stores a lower-triangular matrix L with the same
+// sparsity pattern as the lower triangle of A such
+// that L*L' matches A on that pattern.
+type IncompleteCholesky struct {
+	// L stores the rows of the lower-triangular
+	// factor, each ending with its diagonal entry.
+	L *SparseMatrix
+}
+
+// NewIncompleteCholesky computes the IC(0)
+// factorization of a sparse symmetric matrix, using
+// only its lower triangle.
+//
+// The factorization may break down even for some
+// positive-definite matrices, in which case
+// ErrIncompleteBreakdown is returned.
+// It always succeeds for strictly diagonally dominant
+// matrices with positive diagonals.
+func NewIncompleteCholesky(m *SparseMatrix) (*IncompleteCholesky, error) {
+	lower := NewSparseMatrix(m.Dim())
+	for i, row := range m.Rows {
+		for _, entry := range row {
+			if entry.Col > i {
+				break
+			}
+			lower.Rows[i] = append(lower.Rows[i], entry)
+		}
+		if len(lower.Rows[i]) == 0 || lower.Rows[i][len(lower.Rows[i])-1].Col != i {
+			return nil, ErrIncompleteBreakdown
+		}
+	}
+
+	for i, row := range lower.Rows {
+		for k := range row {
+			col := row[k].Col
+			other := lower.Rows[col]
+			value := row[k].Value - sparseDot(row[:k], other[:len(other)-1])
+			if col == i {
+				if !(value > 0) {
+					return nil, ErrIncompleteBreakdown
+				}
+				row[k].Value = math.Sqrt(value)
+			} else {
+				row[k].Value = value / other[len(other)-1].Value
+			}
+		}
+	}
+
+	return &IncompleteCholesky{L: lower}, nil
+}
+
+func (i *IncompleteCholesky) Dim() int {
+	return i.L.Dim()
+}
+
+// Apply solves L*L'*x = v for x.
+func (i *IncompleteCholesky) Apply(v linalg.Vector) linalg.Vector {
+	if len(v) != i.L.Dim() {
+		panic("dimension mismatch")
+	}
+	res := v.Copy()
+	for j, row := range i.L.Rows {
+		last := len(row) - 1
+		for _, entry := range row[:last] {
+			res[j] -= entry.Value * res[entry.Col]
+		}
+		res[j] /= row[last].Value
+	}
+	for j := len(res) - 1; j >= 0; j-- {
+		row := i.L.Rows[j]
+		last := len(row) - 1
+		res[j] /= row[last].Value
+		for _, entry := range row[:last] {
+			res[entry.Col] -= entry.Value * res[j]
+		}
+	}
+	return res
+}
+
+// sparseDot computes the dot product of two rows
+// whose entries are sorted by column.
+func sparseDot(row1, row2 []SparseEntry) float64 {
+	var sum float64
+	var j int
+	for _, entry := range row1 {
+		for j < len(row2) && row2[j].Col < entry.Col {
+			j++
+		}
+		if j == len(row2) {
+			break
+		}
+		if row2[j].Col == entry.Col {
+			sum += entry.Value * row2[j].Value
+		}
+	}
+	return sum
+}
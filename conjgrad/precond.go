@@ -0,0 +1,181 @@
+package conjgrad
+
+import "github.com/unixpickle/num-analysis/linalg"
+
+// Jacobi is a diagonal preconditioner which divides
+// each component of a vector by the corresponding
+// diagonal entry of a matrix.
+type Jacobi struct {
+	InvDiag linalg.Vector
+}
+
+// NewJacobi creates a Jacobi preconditioner for a
+// matrix with the given diagonal.
+// The diagonal entries must all be non-zero.
+func NewJacobi(diag linalg.Vector) *Jacobi {
+	res := &Jacobi{InvDiag: make(linalg.Vector, len(diag))}
+	for i, x := range diag {
+		if x == 0 {
+			panic("zero diagonal entry")
+		}
+		res.InvDiag[i] = 1 / x
+	}
+	return res
+}
+
+func (j *Jacobi) Dim() int {
+	return len(j.InvDiag)
+}
+
+func (j *Jacobi) Apply(v linalg.Vector) linalg.Vector {
+	if len(v) != len(j.InvDiag) {
+		panic("dimension mismatch")
+	}
+	res := make(linalg.Vector, len(v))
+	for i, x := range v {
+		res[i] = x * j.InvDiag[i]
+	}
+	return res
+}
+
+// LinTranDiagonal computes the diagonal of a LinTran
+// by applying it to each standard basis vector.
+//
+// This takes t.Dim() applications of t, so it should
+// only be used when the diagonal cannot be found
+// more directly.
+func LinTranDiagonal(t LinTran) linalg.Vector {
+	res := make(linalg.Vector, t.Dim())
+	basis := make(linalg.Vector, t.Dim())
+	for i := range res {
+		basis[i] = 1
+		res[i] = t.Apply(basis)[i]
+		basis[i] = 0
+	}
+	return res
+}
+
+// SSOR is a symmetric successive over-relaxation
+// preconditioner for a sparse symmetric matrix.
+// With an Omega of 1, it is equivalent to a symmetric
+// Gauss-Seidel preconditioner.
+type SSOR struct {
+	Matrix *SparseMatrix
+	Omega  float64
+
+	diag linalg.Vector
+}
+
+// NewSSOR creates an SSOR preconditioner for a sparse
+// symmetric matrix with a non-zero diagonal.
+// The omega argument must be in the range (0, 2).
+func NewSSOR(m *SparseMatrix, omega float64) *SSOR {
+	if omega <= 0 || omega >= 2 {
+		panic("omega must be between 0 and 2")
+	}
+	diag := m.Diagonal()
+	for _, x := range diag {
+		if x == 0 {
+			panic("zero diagonal entry")
+		}
+	}
+	return &SSOR{Matrix: m, Omega: omega, diag: diag}
+}
+
+func (s *SSOR) Dim() int {
+	return s.Matrix.Dim()
+}
+
+// Apply applies the inverse of the SSOR matrix
+//
+//	w/(2-w) * (D/w + L) * (D/w)^-1 * (D/w + U)
+//
+// to v, where D, L, and U are the diagonal, lower,
+// and upper parts of the matrix.
+func (s *SSOR) Apply(v linalg.Vector) linalg.Vector {
+	if len(v) != len(s.diag) {
+		panic("dimension mismatch")
+	}
+	n := len(v)
+	res := make(linalg.Vector, n)
+	for i := 0; i < n; i++ {
+		sum := v[i]
+		for _, entry := range s.Matrix.Rows[i] {
+			if entry.Col >= i {
+				break
+			}
+			sum -= entry.Value * res[entry.Col]
+		}
+		res[i] = sum * s.Omega / s.diag[i]
+	}
+	for i := range res {
+		res[i] *= s.diag[i] / s.Omega
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := res[i]
+		row := s.Matrix.Rows[i]
+		for k := len(row) - 1; k >= 0 && row[k].Col > i; k-- {
+			sum -= row[k].Value * res[row[k].Col]
+		}
+		res[i] = sum * s.Omega / s.diag[i]
+	}
+	return res.Scale((2 - s.Omega) / s.Omega)
+}
+
+// Chebyshev is a polynomial preconditioner which
+// approximates the inverse of a symmetric
+// positive-definite LinTran by running a fixed number
+// of Chebyshev iterations.
+//
+// Since it only uses Apply, it works for operators
+// whose entries are not explicitly available.
+type Chebyshev struct {
+	T LinTran
+
+	// MinEig and MaxEig bound the spectrum of T.
+	// MaxEig must not be less than the largest
+	// eigenvalue, but MinEig may be a rough estimate.
+	MinEig float64
+	MaxEig float64
+
+	// Degree is the number of iterations to run.
+	// Each iteration but the first applies T once.
+	Degree int
+}
+
+// NewChebyshev creates a Chebyshev preconditioner.
+func NewChebyshev(t LinTran, minEig, maxEig float64, degree int) *Chebyshev {
+	if minEig <= 0 || maxEig <= minEig {
+		panic("invalid eigenvalue bounds")
+	}
+	if degree < 1 {
+		panic("degree must be positive")
+	}
+	return &Chebyshev{T: t, MinEig: minEig, MaxEig: maxEig, Degree: degree}
+}
+
+func (c *Chebyshev) Dim() int {
+	return c.T.Dim()
+}
+
+func (c *Chebyshev) Apply(v linalg.Vector) linalg.Vector {
+	center := (c.MaxEig + c.MinEig) / 2
+	radius := (c.MaxEig - c.MinEig) / 2
+	sigma := center / radius
+	rho := 1 / sigma
+
+	residual := v.Copy()
+	direction := v.Copy().Scale(1 / center)
+	res := make(linalg.Vector, len(v))
+	for i := 0; i < c.Degree; i++ {
+		res.Add(direction)
+		if i+1 == c.Degree {
+			break
+		}
+		residual.Add(c.T.Apply(direction).Scale(-1))
+		newRho := 1 / (2*sigma - rho)
+		direction.Scale(newRho * rho).Add(residual.Copy().Scale(2 * newRho / radius))
+		rho = newRho
+	}
+	return res
+}
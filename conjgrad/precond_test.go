@@ -0,0 +1,154 @@
+package conjgrad
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestJacobi(t *testing.T) {
+	problem := heterogeneousLaplacian(20)
+	precond := NewJacobi(problem.Diagonal())
+	testPreconditioner(t, problem, precond, nil)
+
+	diag := LinTranDiagonal(problem)
+	for i, x := range problem.Diagonal() {
+		if diag[i] != x {
+			t.Fatal("expected diagonal", problem.Diagonal(), "but got", diag)
+		}
+	}
+}
+
+func TestSSOR(t *testing.T) {
+	problem := heterogeneousLaplacian(20)
+	for _, omega := range []float64{1, 1.5} {
+		testPreconditioner(t, problem, NewSSOR(problem, omega), nil)
+	}
+}
+
+func TestIncompleteCholesky(t *testing.T) {
+	problem := heterogeneousLaplacian(20)
+	precond, err := NewIncompleteCholesky(problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPreconditioner(t, problem, precond, nil)
+
+	// For a tridiagonal matrix, IC(0) is exact.
+	tridiag := NewSparseMatrix(10)
+	for i := 0; i < 10; i++ {
+		tridiag.Set(i, i, 3+rand.Float64())
+		if i > 0 {
+			val := rand.Float64()*2 - 1
+			tridiag.Set(i, i-1, val)
+			tridiag.Set(i-1, i, val)
+		}
+	}
+	precond, err = NewIncompleteCholesky(tridiag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := randomVector(10)
+	solution := precond.Apply(b)
+	verifySolution(t, tridiag, solution, b, 1e-10)
+}
+
+func TestIncompleteCholeskyBreakdown(t *testing.T) {
+	m := NewSparseMatrixDense(&linalg.Matrix{
+		Rows: 2,
+		Cols: 2,
+		Data: []float64{1, 2, 2, 1},
+	})
+	if _, err := NewIncompleteCholesky(m); err != ErrIncompleteBreakdown {
+		t.Error("expected ErrIncompleteBreakdown but got", err)
+	}
+}
+
+func TestChebyshev(t *testing.T) {
+	problem := heterogeneousLaplacian(20)
+
+	// Scaling by the diagonal gives a matrix with
+	// eigenvalues in (0, 2).
+	jacobi := NewJacobi(problem.Diagonal())
+	scaled := NewSparseMatrix(problem.Dim())
+	for i, row := range problem.Rows {
+		for _, entry := range row {
+			val := entry.Value * math.Sqrt(jacobi.InvDiag[i]*jacobi.InvDiag[entry.Col])
+			scaled.Rows[i] = append(scaled.Rows[i], SparseEntry{Col: entry.Col, Value: val})
+		}
+	}
+
+	// The preconditioner applies the matrix itself, so
+	// count those applications against it too.
+	inner := &countingLinTran{LinTran: scaled}
+	precond := NewChebyshev(inner, 0.01, 2, 5)
+	testPreconditioner(t, scaled, precond, inner)
+}
+
+// testPreconditioner checks that precond reduces the
+// number of matrix applications needed to solve a
+// system.
+// If inner is non-nil, it counts applications made by
+// the preconditioner itself, which are added to the
+// total for the preconditioned solve.
+func testPreconditioner(t *testing.T, problem, precond LinTran, inner *countingLinTran) {
+	b := randomVector(problem.Dim())
+
+	plain := &countingLinTran{LinTran: problem}
+	solution := SolvePrec(plain, nil, b, 1e-8)
+	verifySolution(t, problem, solution, b, 1e-8)
+
+	conditioned := &countingLinTran{LinTran: problem}
+	if inner != nil {
+		inner.count = 0
+	}
+	solution = SolvePrec(conditioned, precond, b, 1e-8)
+	verifySolution(t, problem, solution, b, 1e-8)
+	if inner != nil {
+		conditioned.count += inner.count
+	}
+
+	if conditioned.count >= plain.count {
+		t.Errorf("preconditioning took %d applications (vs. %d without)",
+			conditioned.count, plain.count)
+	}
+}
+
+// heterogeneousLaplacian creates a symmetric
+// positive-definite matrix for a diffusion problem on
+// a size-by-size grid with wildly varying coefficients.
+func heterogeneousLaplacian(size int) *SparseMatrix {
+	res := NewSparseMatrix(size * size)
+	addEdge := func(i, j int) {
+		weight := math.Exp(rand.NormFloat64() * 2)
+		res.Set(i, j, res.Get(i, j)-weight)
+		res.Set(j, i, res.Get(j, i)-weight)
+		res.Set(i, i, res.Get(i, i)+weight)
+		res.Set(j, j, res.Get(j, j)+weight)
+	}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			idx := y*size + x
+			res.Set(idx, idx, res.Get(idx, idx)+1e-2)
+			if x+1 < size {
+				addEdge(idx, idx+1)
+			}
+			if y+1 < size {
+				addEdge(idx, idx+size)
+			}
+		}
+	}
+	return res
+}
+
+type countingLinTran struct {
+	LinTran
+	count int
+}
+
+func (c *countingLinTran) Apply(v linalg.Vector) linalg.Vector {
+	c.count++
+	return c.LinTran.Apply(v)
+}
@@ -0,0 +1,154 @@
+package conjgrad
+
+import "github.com/unixpickle/num-analysis/linalg"
+
+// A TerminationReason indicates why an iterative
+// solver stopped.
+type TerminationReason int
+
+const (
+	// Converged indicates that the residual reached
+	// the requested precision.
+	Converged TerminationReason = iota
+
+	// Cancelled indicates that the cancel channel
+	// was closed.
+	Cancelled
+
+	// Stagnated indicates that the solver stopped
+	// making progress or broke down.
+	Stagnated
+
+	// MaxIterations indicates that the iteration
+	// limit was reached.
+	MaxIterations
+)
+
+func (t TerminationReason) String() string {
+	switch t {
+	case Converged:
+		return "converged"
+	case Cancelled:
+		return "cancelled"
+	case Stagnated:
+		return "stagnated"
+	case MaxIterations:
+		return "max iterations"
+	default:
+		return "unknown"
+	}
+}
+
+// Options configures an iterative solver.
+// A nil *Options is equivalent to a zero Options.
+type Options struct {
+	// Precond is an approximation of the inverse of
+	// the operator, or nil for no preconditioning.
+	Precond LinTran
+
+	// Prec is the largest acceptable absolute value
+	// of any component of the residual.
+	Prec float64
+
+	// MaxIters limits the number of iterations.
+	// If it is 0, there is no limit.
+	MaxIters int
+
+	// StagnationLimit is the number of iterations
+	// without any improvement in the residual after
+	// which Solve and BiCGSTAB stop with the reason
+	// Stagnated.
+	// If it is 0, a default of 100 iterations (or twice
+	// the dimension, for 50 or more unknowns) is used.
+	// If it is negative, the solvers never stop for lack
+	// of progress.
+	StagnationLimit int
+
+	// Cancel may be closed to stop the solve early.
+	Cancel <-chan struct{}
+
+	// Callback, if non-nil, is called after every
+	// iteration with the iteration number (starting
+	// at 1) and the solver's residual estimate.
+	Callback func(iter int, residual float64)
+
+	// done is an additional cancel channel used by
+	// the context-based solvers.
+	done <-chan struct{}
+}
+
+// Result describes the outcome of an iterative solve.
+type Result struct {
+	Solution linalg.Vector
+
+	// Iterations is the number of iterations run.
+	Iterations int
+
+	// ResidualHistory stores the solver's residual
+	// estimate after each iteration.
+	//
+	// For most solvers, this is the largest absolute
+	// component of the residual.
+	// For GMRES, it is the Euclidean norm of the
+	// residual, which bounds the largest component.
+	ResidualHistory []float64
+
+	// Residual is the largest absolute component of
+	// the true residual b-t*x for the final solution.
+	Residual float64
+
+	Reason TerminationReason
+}
+
+// step records the residual estimate after an
+// iteration and reports whether the solve should stop
+// because it was cancelled or ran out of iterations.
+func (r *Result) step(opts *Options, residual float64) bool {
+	r.Iterations++
+	r.ResidualHistory = append(r.ResidualHistory, residual)
+	if opts.Callback != nil {
+		opts.Callback(r.Iterations, residual)
+	}
+	if residual <= opts.Prec {
+		return false
+	}
+	if opts.MaxIters > 0 && r.Iterations >= opts.MaxIters {
+		r.Reason = MaxIterations
+		return true
+	}
+	select {
+	case <-opts.Cancel:
+		r.Reason = Cancelled
+		return true
+	case <-opts.done:
+		r.Reason = Cancelled
+		return true
+	default:
+	}
+	return false
+}
+
+// finish stores the solution and its true residual.
+func (r *Result) finish(t LinTran, b, solution linalg.Vector) *Result {
+	r.Solution = solution
+	r.Residual = t.Apply(solution).Scale(-1).Add(b).MaxAbs()
+	return r
+}
+
+// stagnationLimit returns the number of iterations
+// without improvement to allow, or -1 for no limit.
+func (o *Options) stagnationLimit(n int) int {
+	if o.StagnationLimit < 0 {
+		return -1
+	} else if o.StagnationLimit == 0 {
+		return stagnationIterations(n)
+	}
+	return o.StagnationLimit
+}
+
+func (o *Options) precond() LinTran {
+	if o.Precond == nil {
+		return identity{}
+	}
+	return o.Precond
+}
@@ -0,0 +1,127 @@
+package conjgrad
+
+import (
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+type resultSolver func(t LinTran, b linalg.Vector, opts *Options) *Result
+
+var resultSolvers = map[string]resultSolver{
+	"CG": Solve,
+	"GMRES": func(t LinTran, b linalg.Vector, opts *Options) *Result {
+		return GMRES(t, b, DefaultRestart, opts)
+	},
+	"BiCGSTAB": BiCGSTAB,
+}
+
+func TestResultConverged(t *testing.T) {
+	problem := shiftedLaplacian(10)
+	b := randomVector(problem.Dim())
+	for name, solver := range resultSolvers {
+		var callbackIters []int
+		var callbackResiduals []float64
+		opts := &Options{
+			Precond: NewJacobi(problem.Diagonal()),
+			Prec:    1e-8,
+			Callback: func(iter int, residual float64) {
+				callbackIters = append(callbackIters, iter)
+				callbackResiduals = append(callbackResiduals, residual)
+			},
+		}
+		result := solver(problem, b, opts)
+		if result.Reason != Converged {
+			t.Errorf("%s: unexpected reason: %s", name, result.Reason)
+		}
+		if result.Residual > 1e-8 {
+			t.Errorf("%s: residual too large: %e", name, result.Residual)
+		}
+		verifySolution(t, problem, result.Solution, b, 1e-8)
+		if result.Iterations == 0 || len(result.ResidualHistory) != result.Iterations ||
+			len(callbackIters) != result.Iterations {
+			t.Errorf("%s: got %d iterations, %d history entries, and %d callbacks",
+				name, result.Iterations, len(result.ResidualHistory), len(callbackIters))
+			continue
+		}
+		for i, iter := range callbackIters {
+			if iter != i+1 || callbackResiduals[i] != result.ResidualHistory[i] {
+				t.Errorf("%s: bad callback %d: iteration %d, residual %f", name, i,
+					iter, callbackResiduals[i])
+				break
+			}
+		}
+	}
+}
+
+func TestResultMaxIterations(t *testing.T) {
+	problem := shiftedLaplacian(10)
+	b := randomVector(problem.Dim())
+	for name, solver := range resultSolvers {
+		result := solver(problem, b, &Options{Prec: 1e-8, MaxIters: 5})
+		if result.Reason != MaxIterations {
+			t.Errorf("%s: unexpected reason: %s", name, result.Reason)
+		}
+		if result.Iterations != 5 {
+			t.Errorf("%s: expected 5 iterations but got %d", name, result.Iterations)
+		}
+		actual := problem.Apply(result.Solution).Scale(-1).Add(b).MaxAbs()
+		if actual != result.Residual {
+			t.Errorf("%s: expected residual %f but got %f", name, actual, result.Residual)
+		}
+	}
+}
+
+func TestResultCancelled(t *testing.T) {
+	problem := shiftedLaplacian(10)
+	b := randomVector(problem.Dim())
+	cancel := make(chan struct{})
+	close(cancel)
+	for name, solver := range resultSolvers {
+		result := solver(problem, b, &Options{Prec: 1e-8, Cancel: cancel})
+		if result.Reason != Cancelled {
+			t.Errorf("%s: unexpected reason: %s", name, result.Reason)
+		}
+		if result.Iterations != 1 {
+			t.Errorf("%s: expected 1 iteration but got %d", name, result.Iterations)
+		}
+	}
+}
+
+func TestResultStagnated(t *testing.T) {
+	// An inconsistent singular system.
+	problem := MatLinTran{M: randomSingular()}
+	b := linalg.Vector{1, 0, 0}
+	for name, solver := range resultSolvers {
+		if name == "CG" {
+			// CG requires a positive-definite operator.
+			continue
+		}
+		result := solver(problem, b, &Options{Prec: 1e-8})
+		if result.Reason != Stagnated {
+			t.Errorf("%s: unexpected reason: %s", name, result.Reason)
+		}
+	}
+}
+
+func TestResultNilOptions(t *testing.T) {
+	problem := MatLinTran{M: linalg.NewMatrixIdentity(3)}
+	b := linalg.Vector{1, 2, 3}
+	for name, solver := range resultSolvers {
+		result := solver(problem, b, nil)
+		if result.Reason != Converged || result.Residual != 0 {
+			t.Errorf("%s: unexpected result: %v", name, result)
+		}
+	}
+}
+
+// shiftedLaplacian creates a well-conditioned
+// symmetric positive-definite matrix which restarted
+// GMRES can solve quickly.
+func shiftedLaplacian(size int) *SparseMatrix {
+	res := heterogeneousLaplacian(size)
+	for i := range res.Rows {
+		res.Set(i, i, res.Get(i, i)+1)
+	}
+	return res
+}
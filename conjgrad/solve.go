@@ -1,29 +1,31 @@
 package conjgrad
 
-import "github.com/unixpickle/num-analysis/linalg"
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
 
 const residualUpdateFrequency = 20
 
-// SolveStoppable solves a system of linear equations
-// t*x = b for x, where t is a symmetric positive-definite
-// linear operator.
-//
-// If precond is nil, then no preconditioning is used.
-//
-// The prec argument specifies a bound on the
-// residual error of the solution. If the largest
-// element of (Ax-b) has an absolute value less than
-// prec, then the current x is returned.
+// Solve solves a system of linear equations t*x = b
+// for x, where t is a symmetric positive-definite
+// linear operator, and reports how the solve went.
 //
-// The cancelChan argument is a channel which you
-// can close to stop the solve early.
-// If the solve is cancelled, an approximate
-// solution is returned.
-func SolveStoppable(t, precond LinTran, b linalg.Vector, prec float64,
-	cancelChan <-chan struct{}) linalg.Vector {
-	if precond == nil {
-		precond = identity{}
+// The solve stops once the largest element of (tx-b)
+// has an absolute value no greater than opts.Prec, or
+// if the residual does not improve for
+// opts.StagnationLimit iterations.
+// The stagnation check keeps rounding error from
+// making the solve run forever when opts.Prec is too
+// small to reach; set opts.StagnationLimit to a
+// negative value to turn it off.
+func Solve(t LinTran, b linalg.Vector, opts *Options) *Result {
+	if opts == nil {
+		opts = &Options{}
 	}
+	precond := opts.precond()
+	result := &Result{}
 
 	var conjVec linalg.Vector
 	var residual linalg.Vector
@@ -34,7 +36,11 @@ func SolveStoppable(t, precond LinTran, b linalg.Vector, prec float64,
 	residual = b.Copy()
 	solution = make(linalg.Vector, t.Dim())
 
-	for i := 0; residual.MaxAbs() > prec; i++ {
+	bestResidual := residual.MaxAbs()
+	sinceBest := 0
+	stagnationLimit := opts.stagnationLimit(t.Dim())
+
+	for i := 0; residual.MaxAbs() > opts.Prec; i++ {
 		z := precond.Apply(residual)
 		if i == 0 {
 			conjVec = z.Copy()
@@ -46,6 +52,7 @@ func SolveStoppable(t, precond LinTran, b linalg.Vector, prec float64,
 			conjVec = z.Copy().Add(conjVec.Scale(-projAmount))
 		}
 		if allZero(conjVec) {
+			result.Reason = Stagnated
 			break
 		}
 		optimalDistance := z.Dot(residual) / conjVec.Dot(t.Apply(conjVec))
@@ -57,14 +64,51 @@ func SolveStoppable(t, precond LinTran, b linalg.Vector, prec float64,
 			residual.Add(t.Apply(conjVec).Scale(-optimalDistance))
 		}
 
-		select {
-		case <-cancelChan:
-			return solution
-		default:
+		maxRes := residual.MaxAbs()
+		if result.step(opts, maxRes) {
+			break
+		}
+		if math.IsNaN(maxRes) {
+			result.Reason = Stagnated
+			break
+		} else if maxRes < bestResidual {
+			bestResidual = maxRes
+			sinceBest = 0
+		} else if sinceBest++; stagnationLimit >= 0 && sinceBest > stagnationLimit {
+			result.Reason = Stagnated
+			break
 		}
 	}
 
-	return solution
+	return result.finish(t, b, solution)
+}
+
+// SolveStoppable solves a system of linear equations
+// t*x = b for x, where t is a symmetric positive-definite
+// linear operator.
+//
+// If precond is nil, then no preconditioning is used.
+//
+// The prec argument specifies a bound on the
+// residual error of the solution. If the largest
+// element of (Ax-b) has an absolute value less than
+// prec, then the current x is returned.
+//
+// The cancelChan argument is a channel which you
+// can close to stop the solve early.
+// If the solve is cancelled, an approximate
+// solution is returned.
+//
+// Unlike Solve with default Options, this never gives
+// up because the residual stops improving.
+func SolveStoppable(t, precond LinTran, b linalg.Vector, prec float64,
+	cancelChan <-chan struct{}) linalg.Vector {
+	return Solve(t, b, &Options{
+		Precond:         precond,
+		Prec:            prec,
+		Cancel:          cancelChan,
+		StagnationLimit: -1,
+	}).Solution
 }
 
 // SolvePrec is like SolveStoppable, but it does not
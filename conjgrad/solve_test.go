@@ -73,3 +73,35 @@ func TestSolvePrecond(t *testing.T) {
 		}
 	}
 }
+
+func TestSolveStagnationLimit(t *testing.T) {
+	// An ill-conditioned Hilbert matrix, for which the
+	// residual cannot reach the requested precision.
+	n := 8
+	hilbert := linalg.NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			hilbert.Set(i, j, 1/float64(i+j+1))
+		}
+	}
+	problem := MatLinTran{M: hilbert}
+	b := make(linalg.Vector, n)
+	for i := range b {
+		b[i] = 1
+	}
+
+	result := Solve(problem, b, &Options{Prec: 1e-300})
+	if result.Reason != Stagnated || result.Iterations > 1000 {
+		t.Error("unexpected default result:", result.Reason, result.Iterations)
+	}
+
+	result = Solve(problem, b, &Options{Prec: 1e-300, StagnationLimit: 10})
+	if result.Reason != Stagnated {
+		t.Error("unexpected reason with limit:", result.Reason)
+	}
+
+	result = Solve(problem, b, &Options{Prec: 1e-300, StagnationLimit: -1, MaxIters: 2000})
+	if result.Reason != MaxIterations || result.Iterations != 2000 {
+		t.Error("unexpected result without limit:", result.Reason, result.Iterations)
+	}
+}
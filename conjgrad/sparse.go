@@ -0,0 +1,117 @@
+package conjgrad
+
+import (
+	"sort"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A SparseEntry is a non-zero entry in one row of a
+// SparseMatrix.
+type SparseEntry struct {
+	Col   int
+	Value float64
+}
+
+// A SparseMatrix is a square matrix which stores
+// only its non-zero entries.
+// Each row's entries are sorted by column.
+//
+// SparseMatrix implements LinTran.
+type SparseMatrix struct {
+	Rows [][]SparseEntry
+}
+
+// NewSparseMatrix creates a size-by-size sparse
+// matrix with no non-zero entries.
+func NewSparseMatrix(size int) *SparseMatrix {
+	return &SparseMatrix{Rows: make([][]SparseEntry, size)}
+}
+
+// NewSparseMatrixDense creates a sparse matrix from
+// the non-zero entries of a dense square matrix.
+func NewSparseMatrixDense(m *linalg.Matrix) *SparseMatrix {
+	if m.Rows != m.Cols {
+		panic("matrix must be square")
+	}
+	res := NewSparseMatrix(m.Rows)
+	for i := range res.Rows {
+		for j := 0; j < m.Cols; j++ {
+			if val := m.Get(i, j); val != 0 {
+				res.Rows[i] = append(res.Rows[i], SparseEntry{Col: j, Value: val})
+			}
+		}
+	}
+	return res
+}
+
+// Get returns the entry at the given row and column.
+func (s *SparseMatrix) Get(row, col int) float64 {
+	entries := s.Rows[row]
+	idx := sort.Search(len(entries), func(i int) bool {
+		return entries[i].Col >= col
+	})
+	if idx < len(entries) && entries[idx].Col == col {
+		return entries[idx].Value
+	}
+	return 0
+}
+
+// Set sets the entry at the given row and column,
+// inserting it into the row if necessary.
+func (s *SparseMatrix) Set(row, col int, val float64) {
+	if col < 0 || col >= len(s.Rows) {
+		panic("index out of bounds")
+	}
+	entries := s.Rows[row]
+	idx := sort.Search(len(entries), func(i int) bool {
+		return entries[i].Col >= col
+	})
+	if idx < len(entries) && entries[idx].Col == col {
+		entries[idx].Value = val
+		return
+	}
+	entries = append(entries, SparseEntry{})
+	copy(entries[idx+1:], entries[idx:])
+	entries[idx] = SparseEntry{Col: col, Value: val}
+	s.Rows[row] = entries
+}
+
+// Diagonal returns the diagonal of the matrix.
+func (s *SparseMatrix) Diagonal() linalg.Vector {
+	res := make(linalg.Vector, len(s.Rows))
+	for i := range res {
+		res[i] = s.Get(i, i)
+	}
+	return res
+}
+
+func (s *SparseMatrix) Dim() int {
+	return len(s.Rows)
+}
+
+func (s *SparseMatrix) Apply(v linalg.Vector) linalg.Vector {
+	if len(v) != len(s.Rows) {
+		panic("dimension mismatch")
+	}
+	res := make(linalg.Vector, len(v))
+	for i, row := range s.Rows {
+		var sum float64
+		for _, entry := range row {
+			sum += entry.Value * v[entry.Col]
+		}
+		res[i] = sum
+	}
+	return res
+}
+
+// Dense returns a dense copy of the matrix.
+func (s *SparseMatrix) Dense() *linalg.Matrix {
+	res := linalg.NewMatrix(len(s.Rows), len(s.Rows))
+	for i, row := range s.Rows {
+		for _, entry := range row {
+			res.Set(i, entry.Col, entry.Value)
+		}
+	}
+	return res
+}
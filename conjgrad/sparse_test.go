@@ -0,0 +1,43 @@
+package conjgrad
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestSparseMatrix(t *testing.T) {
+	dense := linalg.NewMatrix(6, 6)
+	sparse := NewSparseMatrix(6)
+	for _, idx := range rand.Perm(36)[:15] {
+		val := rand.NormFloat64()
+		dense.Data[idx] = val
+		sparse.Set(idx/6, idx%6, val)
+	}
+	for _, row := range sparse.Rows {
+		for i := 1; i < len(row); i++ {
+			if row[i].Col <= row[i-1].Col {
+				t.Fatal("row is not sorted:", row)
+			}
+		}
+	}
+	if !reflect.DeepEqual(sparse.Dense().Data, dense.Data) {
+		t.Error("expected", dense, "but got", sparse.Dense())
+	}
+	if !reflect.DeepEqual(NewSparseMatrixDense(dense).Dense().Data, dense.Data) {
+		t.Error("NewSparseMatrixDense did not round trip")
+	}
+
+	v := randomVector(6)
+	expected := MatLinTran{M: dense}.Apply(v)
+	actual := sparse.Apply(v)
+	for i, x := range expected {
+		if math.Abs(x-actual[i]) > 1e-10 {
+			t.Error("expected product", expected, "but got", actual)
+			break
+		}
+	}
+}
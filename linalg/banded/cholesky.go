@@ -0,0 +1,86 @@
+package banded
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/kahan"
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/cholesky"
+)
+
+// Cholesky stores the Cholesky decomposition L*L'
+// of a banded symmetric positive-definite matrix.
+type Cholesky struct {
+	// L is the lower-triangular factor, which has
+	// the same lower bandwidth as the original
+	// matrix.
+	L *Matrix
+}
+
+// DecomposeCholesky computes the Cholesky
+// decomposition of a symmetric positive-definite
+// banded matrix in O(N*Lower^2) time.
+//
+// Only the diagonal and the lower band of m are
+// accessed.
+// If a non-positive pivot is encountered, then
+// cholesky.ErrNotPositiveDefinite is returned.
+func DecomposeCholesky(m *Matrix) (*Cholesky, error) {
+	n := m.Size
+	band := m.Lower
+	lower := NewMatrix(n, band, 0)
+
+	for i := 0; i < n; i++ {
+		start, _ := m.rowRange(i)
+		for j := start; j <= i; j++ {
+			summer := kahan.NewSummer64()
+			summer.Add(m.Get(i, j))
+			for k := start; k < j; k++ {
+				summer.Add(-lower.Get(i, k) * lower.Get(j, k))
+			}
+			sum := summer.Sum()
+			if j == i {
+				if !(sum > 0) {
+					return nil, cholesky.ErrNotPositiveDefinite
+				}
+				lower.Set(i, i, math.Sqrt(sum))
+			} else {
+				lower.Set(i, j, sum/lower.Get(j, j))
+			}
+		}
+	}
+
+	return &Cholesky{L: lower}, nil
+}
+
+// Solve solves the system (L*L')x = b for x.
+func (c *Cholesky) Solve(b linalg.Vector) linalg.Vector {
+	n := c.L.Size
+	band := c.L.Lower
+	if len(b) != n {
+		panic("dimension mismatch")
+	}
+
+	y := b.Copy()
+	for i := 0; i < n; i++ {
+		summer := kahan.NewSummer64()
+		summer.Add(y[i])
+		for j := i - band; j < i; j++ {
+			if j >= 0 {
+				summer.Add(-c.L.Get(i, j) * y[j])
+			}
+		}
+		y[i] = summer.Sum() / c.L.Get(i, i)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		summer := kahan.NewSummer64()
+		summer.Add(y[i])
+		for j := i + 1; j < n && j <= i+band; j++ {
+			summer.Add(-c.L.Get(j, i) * y[j])
+		}
+		y[i] = summer.Sum() / c.L.Get(i, i)
+	}
+
+	return y
+}
@@ -0,0 +1,42 @@
+package banded
+
+import (
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg/cholesky"
+)
+
+func TestCholeskySolve(t *testing.T) {
+	// A finite difference fourth derivative,
+	// which is symmetric positive-definite.
+	mat := NewMatrix(25, 2, 2)
+	for i := 0; i < mat.Size; i++ {
+		mat.Set(i, i, 6)
+		if i > 0 {
+			mat.Set(i, i-1, -4)
+			mat.Set(i-1, i, -4)
+		}
+		if i > 1 {
+			mat.Set(i, i-2, 1)
+			mat.Set(i-2, i, 1)
+		}
+	}
+	dec, err := DecomposeCholesky(mat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := randVec(25)
+	verifySolution(t, mat.Dense(), dec.Solve(b), b)
+}
+
+func TestCholeskyIndefinite(t *testing.T) {
+	mat := NewMatrix(3, 1, 1)
+	mat.Set(0, 0, 1)
+	mat.Set(1, 0, 2)
+	mat.Set(1, 1, 1)
+	mat.Set(2, 1, 1)
+	mat.Set(2, 2, 1)
+	if _, err := DecomposeCholesky(mat); err != cholesky.ErrNotPositiveDefinite {
+		t.Error("expected ErrNotPositiveDefinite but got", err)
+	}
+}
@@ -0,0 +1,117 @@
+package banded
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/kahan"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// LU stores the LU decomposition of a banded
+// matrix, computed with partial pivoting.
+//
+// Row swaps can increase the upper bandwidth of U
+// by the lower bandwidth of the original matrix,
+// but the bandwidth of U never exceeds Lower+Upper.
+type LU struct {
+	// U is the upper-triangular factor.
+	U *Matrix
+
+	// multipliers stores the entries of L below the
+	// diagonal, Lower entries per column.
+	multipliers []float64
+
+	// pivots[k] is the row which was swapped with
+	// row k during the k-th step of elimination.
+	pivots []int
+}
+
+// DecomposeLU computes the LU decomposition of a
+// banded matrix in O(N*Lower*(Lower+Upper)) time.
+func DecomposeLU(m *Matrix) *LU {
+	n := m.Size
+	u := NewMatrix(n, m.Lower, m.Lower+m.Upper)
+	for i := 0; i < n; i++ {
+		start, end := m.rowRange(i)
+		for j := start; j < end; j++ {
+			u.Set(i, j, m.Get(i, j))
+		}
+	}
+	res := &LU{
+		U:           u,
+		multipliers: make([]float64, n*m.Lower),
+		pivots:      make([]int, n),
+	}
+
+	for k := 0; k < n; k++ {
+		lastRow := k + m.Lower
+		if lastRow >= n {
+			lastRow = n - 1
+		}
+		lastCol := k + u.Upper
+		if lastCol >= n {
+			lastCol = n - 1
+		}
+
+		pivot := k
+		for i := k + 1; i <= lastRow; i++ {
+			if math.Abs(u.Get(i, k)) > math.Abs(u.Get(pivot, k)) {
+				pivot = i
+			}
+		}
+		res.pivots[k] = pivot
+		if pivot != k {
+			for j := k; j <= lastCol; j++ {
+				v1, v2 := u.Get(k, j), u.Get(pivot, j)
+				u.Set(k, j, v2)
+				u.Set(pivot, j, v1)
+			}
+		}
+
+		pivotVal := u.Get(k, k)
+		if pivotVal == 0 {
+			continue
+		}
+		for i := k + 1; i <= lastRow; i++ {
+			scale := u.Get(i, k) / pivotVal
+			res.multipliers[k*m.Lower+i-k-1] = scale
+			u.Set(i, k, 0)
+			for j := k + 1; j <= lastCol; j++ {
+				u.Set(i, j, u.Get(i, j)-scale*u.Get(k, j))
+			}
+		}
+	}
+
+	return res
+}
+
+// Solve solves the system A*x = b for x, where A
+// is the decomposed matrix.
+func (l *LU) Solve(b linalg.Vector) linalg.Vector {
+	n := l.U.Size
+	lower := l.U.Lower
+	if len(b) != n {
+		panic("dimension mismatch")
+	}
+
+	y := b.Copy()
+	for k := 0; k < n; k++ {
+		if p := l.pivots[k]; p != k {
+			y[k], y[p] = y[p], y[k]
+		}
+		for i := k + 1; i < n && i <= k+lower; i++ {
+			y[i] -= l.multipliers[k*lower+i-k-1] * y[k]
+		}
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		summer := kahan.NewSummer64()
+		summer.Add(y[i])
+		for j := i + 1; j < n && j <= i+l.U.Upper; j++ {
+			summer.Add(-l.U.Get(i, j) * y[j])
+		}
+		y[i] = summer.Sum() / l.U.Get(i, i)
+	}
+
+	return y
+}
@@ -0,0 +1,49 @@
+package banded
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestLUSolve(t *testing.T) {
+	for _, bands := range [][2]int{{0, 0}, {1, 1}, {2, 1}, {1, 3}, {4, 4}} {
+		mat := randomBanded(30, bands[0], bands[1])
+		b := randVec(30)
+		verifySolution(t, mat.Dense(), DecomposeLU(mat).Solve(b), b)
+	}
+}
+
+func TestLUPivoting(t *testing.T) {
+	// Without pivoting, the zero diagonal would
+	// cause a division by zero.
+	mat := NewMatrixDense(&linalg.Matrix{
+		Rows: 4,
+		Cols: 4,
+		Data: []float64{
+			0, 1, 0, 0,
+			2, 0, 3, 0,
+			0, 1, 0, 4,
+			0, 0, 5, 1,
+		},
+	}, 1, 1)
+	b := linalg.Vector{1, 2, 3, 4}
+	verifySolution(t, mat.Dense(), DecomposeLU(mat).Solve(b), b)
+}
+
+func BenchmarkLU1000Band5(b *testing.B) {
+	mat := randomBanded(1000, 5, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DecomposeLU(mat)
+	}
+}
+
+func randomBanded(size, lower, upper int) *Matrix {
+	res := NewMatrix(size, lower, upper)
+	for i := range res.Data {
+		res.Data[i] = rand.Float64()*2 - 1
+	}
+	return res
+}
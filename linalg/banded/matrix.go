@@ -0,0 +1,132 @@
+// Package banded provides storage and solvers for
+// banded matrices, including tridiagonal matrices.
+//
+// The solvers in this package take advantage of the
+// band structure, running in O(N*B^2) time for an
+// NxN matrix of bandwidth B.
+package banded
+
+import (
+	"github.com/unixpickle/num-analysis/kahan"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A Matrix is a square matrix whose non-zero
+// entries are confined to a band around the
+// diagonal.
+type Matrix struct {
+	Size int
+
+	// Lower is the number of non-zero diagonals
+	// below the main diagonal.
+	Lower int
+
+	// Upper is the number of non-zero diagonals
+	// above the main diagonal.
+	Upper int
+
+	// Data stores the band row by row.
+	// Each row stores Lower+Upper+1 entries,
+	// starting with the entry Lower columns
+	// before the main diagonal.
+	Data []float64
+}
+
+// NewMatrix creates a banded matrix with zeroes
+// in every entry.
+func NewMatrix(size, lower, upper int) *Matrix {
+	return &Matrix{
+		Size:  size,
+		Lower: lower,
+		Upper: upper,
+		Data:  make([]float64, size*(lower+upper+1)),
+	}
+}
+
+// NewMatrixDense creates a banded matrix from the
+// entries of a dense matrix which lie in the band.
+// Entries outside of the band are ignored.
+func NewMatrixDense(m *linalg.Matrix, lower, upper int) *Matrix {
+	if !m.Square() {
+		panic("dimension mismatch")
+	}
+	res := NewMatrix(m.Rows, lower, upper)
+	for i := 0; i < m.Rows; i++ {
+		start, end := res.rowRange(i)
+		for j := start; j < end; j++ {
+			res.Set(i, j, m.Get(i, j))
+		}
+	}
+	return res
+}
+
+// Get returns the entry at the i-th row and the
+// j-th column, which is 0 outside of the band.
+func (m *Matrix) Get(i, j int) float64 {
+	if j < i-m.Lower || j > i+m.Upper {
+		return 0
+	}
+	return m.Data[m.index(i, j)]
+}
+
+// Set updates the entry at the i-th row and the
+// j-th column, which must lie inside the band.
+func (m *Matrix) Set(i, j int, val float64) {
+	if j < i-m.Lower || j > i+m.Upper {
+		panic("index outside of band")
+	}
+	m.Data[m.index(i, j)] = val
+}
+
+// Dim returns the size of m, making m a
+// conjgrad.LinTran.
+func (m *Matrix) Dim() int {
+	return m.Size
+}
+
+// Apply multiplies m by a column vector.
+func (m *Matrix) Apply(v linalg.Vector) linalg.Vector {
+	if len(v) != m.Size {
+		panic("dimension mismatch")
+	}
+	res := make(linalg.Vector, m.Size)
+	for i := range res {
+		start, end := m.rowRange(i)
+		summer := kahan.NewSummer64()
+		for j := start; j < end; j++ {
+			summer.Add(m.Get(i, j) * v[j])
+		}
+		res[i] = summer.Sum()
+	}
+	return res
+}
+
+// Dense returns a dense representation of m.
+func (m *Matrix) Dense() *linalg.Matrix {
+	res := linalg.NewMatrix(m.Size, m.Size)
+	for i := 0; i < m.Size; i++ {
+		start, end := m.rowRange(i)
+		for j := start; j < end; j++ {
+			res.Set(i, j, m.Get(i, j))
+		}
+	}
+	return res
+}
+
+func (m *Matrix) index(i, j int) int {
+	return i*(m.Lower+m.Upper+1) + j - i + m.Lower
+}
+
+// rowRange returns the range [start, end) of
+// columns in the band for row i.
+func (m *Matrix) rowRange(i int) (start, end int) {
+	start = i - m.Lower
+	if start < 0 {
+		start = 0
+	}
+	end = i + m.Upper + 1
+	if end > m.Size {
+		end = m.Size
+	}
+	return
+}
@@ -0,0 +1,170 @@
+package banded
+
+import "github.com/unixpickle/num-analysis/linalg"
+
+// Tridiagonal is a square matrix whose only
+// non-zero entries lie on the main diagonal and
+// the diagonals directly above and below it.
+type Tridiagonal struct {
+	// Lower is the sub-diagonal, where Lower[i] is
+	// the entry at row i+1 and column i.
+	Lower linalg.Vector
+
+	// Diag is the main diagonal.
+	Diag linalg.Vector
+
+	// Upper is the super-diagonal, where Upper[i]
+	// is the entry at row i and column i+1.
+	Upper linalg.Vector
+}
+
+// Dim returns the size of the matrix, making t
+// a conjgrad.LinTran.
+func (t *Tridiagonal) Dim() int {
+	return len(t.Diag)
+}
+
+// Apply multiplies t by a column vector.
+func (t *Tridiagonal) Apply(v linalg.Vector) linalg.Vector {
+	if len(v) != len(t.Diag) {
+		panic("dimension mismatch")
+	}
+	res := make(linalg.Vector, len(v))
+	for i, d := range t.Diag {
+		res[i] = d * v[i]
+		if i > 0 {
+			res[i] += t.Lower[i-1] * v[i-1]
+		}
+		if i+1 < len(v) {
+			res[i] += t.Upper[i] * v[i+1]
+		}
+	}
+	return res
+}
+
+// Solve solves the system t*x = b for x using the
+// Thomas algorithm in O(N) time.
+//
+// The Thomas algorithm does not pivot, so it is
+// only guaranteed to be stable when t is diagonally
+// dominant or symmetric positive-definite.
+// For other matrices, use DecomposeLU.
+func (t *Tridiagonal) Solve(b linalg.Vector) linalg.Vector {
+	n := len(t.Diag)
+	if n == 0 {
+		if len(b) != 0 || len(t.Lower) != 0 || len(t.Upper) != 0 {
+			panic("dimension mismatch")
+		}
+		return linalg.Vector{}
+	}
+	if len(b) != n || len(t.Lower) != n-1 || len(t.Upper) != n-1 {
+		panic("dimension mismatch")
+	}
+
+	upper := make(linalg.Vector, n)
+	res := make(linalg.Vector, n)
+
+	res[0] = b[0] / t.Diag[0]
+	if n > 1 {
+		upper[0] = t.Upper[0] / t.Diag[0]
+	}
+	for i := 1; i < n; i++ {
+		denom := t.Diag[i] - t.Lower[i-1]*upper[i-1]
+		if i+1 < n {
+			upper[i] = t.Upper[i] / denom
+		}
+		res[i] = (b[i] - t.Lower[i-1]*res[i-1]) / denom
+	}
+
+	for i := n - 2; i >= 0; i-- {
+		res[i] -= upper[i] * res[i+1]
+	}
+	return res
+}
+
+// Dense returns a dense representation of t.
+func (t *Tridiagonal) Dense() *linalg.Matrix {
+	n := len(t.Diag)
+	res := linalg.NewMatrix(n, n)
+	for i, d := range t.Diag {
+		res.Set(i, i, d)
+		if i > 0 {
+			res.Set(i, i-1, t.Lower[i-1])
+		}
+		if i+1 < n {
+			res.Set(i, i+1, t.Upper[i])
+		}
+	}
+	return res
+}
+
+// CyclicTridiagonal is a Tridiagonal matrix with
+// additional entries in its top-right and
+// bottom-left corners, as arises from problems
+// with periodic boundary conditions.
+type CyclicTridiagonal struct {
+	Tridiagonal
+
+	// TopRight is the entry at row 0 and
+	// column N-1.
+	TopRight float64
+
+	// BottomLeft is the entry at row N-1 and
+	// column 0.
+	BottomLeft float64
+}
+
+// Apply multiplies c by a column vector.
+func (c *CyclicTridiagonal) Apply(v linalg.Vector) linalg.Vector {
+	res := c.Tridiagonal.Apply(v)
+	n := len(v)
+	res[0] += c.TopRight * v[n-1]
+	res[n-1] += c.BottomLeft * v[0]
+	return res
+}
+
+// Solve solves the system c*x = b for x in O(N)
+// time, using the Sherman-Morrison formula to
+// reduce the problem to two tridiagonal solves.
+//
+// The matrix must have at least 3 rows and a
+// non-zero entry in its top-left corner, and the
+// same stability caveats apply as for
+// Tridiagonal.Solve.
+func (c *CyclicTridiagonal) Solve(b linalg.Vector) linalg.Vector {
+	n := len(c.Diag)
+	if n < 3 {
+		panic("cyclic system must be at least 3x3")
+	}
+	if len(b) != n {
+		panic("dimension mismatch")
+	}
+
+	gamma := -c.Diag[0]
+	modified := Tridiagonal{
+		Lower: c.Lower,
+		Diag:  c.Diag.Copy(),
+		Upper: c.Upper,
+	}
+	modified.Diag[0] -= gamma
+	modified.Diag[n-1] -= c.BottomLeft * c.TopRight / gamma
+
+	x := modified.Solve(b)
+	u := make(linalg.Vector, n)
+	u[0] = gamma
+	u[n-1] = c.BottomLeft
+	z := modified.Solve(u)
+
+	numerator := x[0] + c.TopRight*x[n-1]/gamma
+	denominator := 1 + z[0] + c.TopRight*z[n-1]/gamma
+	return x.Add(z.Scale(-numerator / denominator))
+}
+
+// Dense returns a dense representation of c.
+func (c *CyclicTridiagonal) Dense() *linalg.Matrix {
+	res := c.Tridiagonal.Dense()
+	n := len(c.Diag)
+	res.Set(0, n-1, res.Get(0, n-1)+c.TopRight)
+	res.Set(n-1, 0, res.Get(n-1, 0)+c.BottomLeft)
+	return res
+}
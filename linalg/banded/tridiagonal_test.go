@@ -0,0 +1,78 @@
+package banded
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestTridiagonalSolve(t *testing.T) {
+	mat := randomTridiagonal(20)
+	b := randVec(20)
+	verifySolution(t, mat.Dense(), mat.Solve(b), b)
+}
+
+func TestTridiagonalSolveEmpty(t *testing.T) {
+	mat := &Tridiagonal{}
+	if res := mat.Solve(linalg.Vector{}); len(res) != 0 {
+		t.Error("expected empty solution but got", res)
+	}
+}
+
+func TestCyclicTridiagonalSolve(t *testing.T) {
+	mat := &CyclicTridiagonal{
+		Tridiagonal: *randomTridiagonal(20),
+		TopRight:    rand.Float64(),
+		BottomLeft:  rand.Float64(),
+	}
+	b := randVec(20)
+	verifySolution(t, mat.Dense(), mat.Solve(b), b)
+
+	applied := mat.Apply(b)
+	expected := mat.Dense().Mul(linalg.NewMatrixColumn(b)).Col(0)
+	if vectorDiff(applied, expected) > 1e-10 {
+		t.Error("expected product", expected, "but got", applied)
+	}
+}
+
+func BenchmarkTridiagonalSolve1000(b *testing.B) {
+	mat := randomTridiagonal(1000)
+	vec := randVec(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Solve(vec)
+	}
+}
+
+func randomTridiagonal(size int) *Tridiagonal {
+	res := &Tridiagonal{
+		Lower: randVec(size - 1),
+		Diag:  randVec(size),
+		Upper: randVec(size - 1),
+	}
+	for i := range res.Diag {
+		// Make the matrix diagonally dominant.
+		res.Diag[i] += 2
+	}
+	return res
+}
+
+func verifySolution(t *testing.T, mat *linalg.Matrix, solution, b linalg.Vector) {
+	product := mat.Mul(linalg.NewMatrixColumn(solution)).Col(0)
+	if diff := vectorDiff(product, b); diff > 1e-10 {
+		t.Error("bad solution with error", diff)
+	}
+}
+
+func vectorDiff(v1, v2 linalg.Vector) float64 {
+	return v1.Copy().Add(v2.Copy().Scale(-1)).MaxAbs()
+}
+
+func randVec(size int) linalg.Vector {
+	res := make(linalg.Vector, size)
+	for i := range res {
+		res[i] = rand.Float64()*2 - 1
+	}
+	return res
+}
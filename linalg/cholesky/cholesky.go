@@ -54,6 +54,22 @@ func Decompose(matrix *linalg.Matrix) *Cholesky {
 	return res
 }
 
+// DecomposeChecked is like Decompose, but it returns
+// ErrNotPositiveDefinite if the matrix turns out not
+// to be positive definite.
+//
+// Like Decompose, this does not check that the given
+// matrix is symmetric.
+func DecomposeChecked(matrix *linalg.Matrix) (*Cholesky, error) {
+	res := Decompose(matrix)
+	for i := 0; i < res.size; i++ {
+		if diag := res.Get(i, i); !(diag > 0) || math.IsInf(diag, 1) {
+			return nil, ErrNotPositiveDefinite
+		}
+	}
+	return res, nil
+}
+
 // Size returns N for this NxN matrix.
 func (c *Cholesky) Size() int {
 	return c.size
@@ -39,6 +39,27 @@ func TestSolve3x3(t *testing.T) {
 
 }
 
+func TestDecomposeChecked(t *testing.T) {
+	mat := &linalg.Matrix{
+		Rows: 3,
+		Cols: 3,
+		Data: []float64{
+			14, 26, 17,
+			26, 57, 32,
+			17, 32, 25,
+		},
+	}
+	if _, err := DecomposeChecked(mat); err != nil {
+		t.Error(err)
+	}
+	for _, diag := range []float64{-1, 0} {
+		mat.Set(2, 2, diag)
+		if _, err := DecomposeChecked(mat); err != ErrNotPositiveDefinite {
+			t.Error("expected ErrNotPositiveDefinite but got", err)
+		}
+	}
+}
+
 func BenchmarkDecompose200x200(b *testing.B) {
 	matrix := randMatrix(200)
 	b.ResetTimer()
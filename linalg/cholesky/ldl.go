@@ -0,0 +1,251 @@
+package cholesky
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/kahan"
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+// bunchKaufmanAlpha is the pivoting threshold which
+// minimizes the element growth bound of Bunch-Kaufman
+// pivoting.
+var bunchKaufmanAlpha = (1 + math.Sqrt(17)) / 8
+
+// LDL represents the decomposition P*A*P' = L*D*L'
+// of a symmetric (possibly indefinite) matrix A.
+type LDL struct {
+	// L is a unit lower-triangular matrix.
+	L *linalg.Matrix
+
+	// D is a block-diagonal matrix made up of 1x1
+	// and symmetric 2x2 blocks.
+	D *linalg.Matrix
+
+	// Perm is the symmetric permutation P, such that
+	// (P*A*P')[i][j] = A[Perm[i]][Perm[j]].
+	Perm ludecomp.Perm
+}
+
+// DecomposeLDL computes the LDL' decomposition of a
+// symmetric matrix using Bunch-Kaufman pivoting.
+//
+// Like Decompose, this only accesses the upper
+// triangular portion of the given matrix.
+func DecomposeLDL(matrix *linalg.Matrix) *LDL {
+	if !matrix.Square() {
+		panic("dimension mismatch")
+	}
+	n := matrix.Rows
+	a := symmetricCopy(matrix)
+	res := &LDL{
+		L:    linalg.NewMatrixIdentity(n),
+		D:    linalg.NewMatrix(n, n),
+		Perm: ludecomp.IdentityPerm(n),
+	}
+
+	for k := 0; k < n; {
+		var lambda float64
+		r := k
+		for i := k + 1; i < n; i++ {
+			if x := math.Abs(a.Get(i, k)); x > lambda {
+				lambda = x
+				r = i
+			}
+		}
+
+		blockSize := 1
+		diag := math.Abs(a.Get(k, k))
+		if lambda != 0 && diag < bunchKaufmanAlpha*lambda {
+			var sigma float64
+			for j := k; j < n; j++ {
+				if j != r {
+					sigma = math.Max(sigma, math.Abs(a.Get(r, j)))
+				}
+			}
+			if diag*sigma >= bunchKaufmanAlpha*lambda*lambda {
+				// Use a[k][k] as a 1x1 pivot.
+			} else if math.Abs(a.Get(r, r)) >= bunchKaufmanAlpha*sigma {
+				res.swap(a, k, k, r)
+			} else {
+				res.swap(a, k, k+1, r)
+				blockSize = 2
+			}
+		}
+
+		if blockSize == 1 {
+			res.eliminate1x1(a, k)
+		} else {
+			res.eliminate2x2(a, k)
+		}
+		k += blockSize
+	}
+
+	return res
+}
+
+// Solve solves the system A*x = b for x.
+func (l *LDL) Solve(b linalg.Vector) linalg.Vector {
+	n := l.L.Rows
+	if len(b) != n {
+		panic("dimension mismatch")
+	}
+	y := l.Perm.Apply(b)
+
+	for i := 0; i < n; i++ {
+		summer := kahan.NewSummer64()
+		summer.Add(y[i])
+		for j := 0; j < i; j++ {
+			summer.Add(-l.L.Get(i, j) * y[j])
+		}
+		y[i] = summer.Sum()
+	}
+
+	for i := 0; i < n; {
+		if i+1 < n && l.D.Get(i+1, i) != 0 {
+			e11, e21, e22 := l.D.Get(i, i), l.D.Get(i+1, i), l.D.Get(i+1, i+1)
+			det := e11*e22 - e21*e21
+			y1, y2 := y[i], y[i+1]
+			y[i] = (e22*y1 - e21*y2) / det
+			y[i+1] = (e11*y2 - e21*y1) / det
+			i += 2
+		} else {
+			y[i] /= l.D.Get(i, i)
+			i++
+		}
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		summer := kahan.NewSummer64()
+		summer.Add(y[i])
+		for j := i + 1; j < n; j++ {
+			summer.Add(-l.L.Get(j, i) * y[j])
+		}
+		y[i] = summer.Sum()
+	}
+
+	return l.Perm.Inverse().Apply(y)
+}
+
+// Inertia returns the number of positive, negative,
+// and zero eigenvalues of the decomposed matrix.
+//
+// By Sylvester's law of inertia, these are the same
+// as the counts for the eigenvalues of D.
+func (l *LDL) Inertia() (pos, neg, zero int) {
+	n := l.D.Rows
+	for i := 0; i < n; {
+		if i+1 < n && l.D.Get(i+1, i) != 0 {
+			e11, e21, e22 := l.D.Get(i, i), l.D.Get(i+1, i), l.D.Get(i+1, i+1)
+			det := e11*e22 - e21*e21
+			if det < 0 {
+				pos++
+				neg++
+			} else if e11+e22 > 0 {
+				pos += 2
+			} else {
+				neg += 2
+			}
+			i += 2
+		} else {
+			d := l.D.Get(i, i)
+			if d > 0 {
+				pos++
+			} else if d < 0 {
+				neg++
+			} else {
+				zero++
+			}
+			i++
+		}
+	}
+	return
+}
+
+func (l *LDL) eliminate1x1(a *linalg.Matrix, k int) {
+	n := a.Rows
+	d := a.Get(k, k)
+	l.D.Set(k, k, d)
+	if d == 0 {
+		// The rest of the column is zero, so
+		// there is nothing to eliminate.
+		return
+	}
+	for i := k + 1; i < n; i++ {
+		l.L.Set(i, k, a.Get(i, k)/d)
+	}
+	for i := k + 1; i < n; i++ {
+		scale := l.L.Get(i, k)
+		for j := k + 1; j < n; j++ {
+			a.Set(i, j, a.Get(i, j)-scale*a.Get(j, k))
+		}
+	}
+}
+
+func (l *LDL) eliminate2x2(a *linalg.Matrix, k int) {
+	n := a.Rows
+	e11, e21, e22 := a.Get(k, k), a.Get(k+1, k), a.Get(k+1, k+1)
+	l.D.Set(k, k, e11)
+	l.D.Set(k+1, k, e21)
+	l.D.Set(k, k+1, e21)
+	l.D.Set(k+1, k+1, e22)
+
+	det := e11*e22 - e21*e21
+	for i := k + 2; i < n; i++ {
+		c1, c2 := a.Get(i, k), a.Get(i, k+1)
+		l.L.Set(i, k, (c1*e22-c2*e21)/det)
+		l.L.Set(i, k+1, (c2*e11-c1*e21)/det)
+	}
+	for i := k + 2; i < n; i++ {
+		scale1, scale2 := l.L.Get(i, k), l.L.Get(i, k+1)
+		for j := k + 2; j < n; j++ {
+			a.Set(i, j, a.Get(i, j)-scale1*a.Get(j, k)-scale2*a.Get(j, k+1))
+		}
+	}
+}
+
+// swap applies a symmetric permutation to the
+// working matrix a, swapping indices i and j.
+// The rows of the first step columns of L are
+// swapped accordingly.
+func (l *LDL) swap(a *linalg.Matrix, step, i, j int) {
+	if i == j {
+		return
+	}
+	swapSymmetric(a, i, j)
+	for k := 0; k < step; k++ {
+		v1, v2 := l.L.Get(i, k), l.L.Get(j, k)
+		l.L.Set(i, k, v2)
+		l.L.Set(j, k, v1)
+	}
+	l.Perm.Swap(i, j)
+}
+
+// symmetricCopy creates a symmetric matrix from the
+// upper triangular portion of m.
+func symmetricCopy(m *linalg.Matrix) *linalg.Matrix {
+	res := linalg.NewMatrix(m.Rows, m.Cols)
+	for i := 0; i < m.Rows; i++ {
+		for j := i; j < m.Cols; j++ {
+			res.Set(i, j, m.Get(i, j))
+			res.Set(j, i, m.Get(i, j))
+		}
+	}
+	return res
+}
+
+// swapSymmetric swaps both the rows and the columns
+// i and j of a matrix.
+func swapSymmetric(m *linalg.Matrix, i, j int) {
+	for k := 0; k < m.Cols; k++ {
+		v1, v2 := m.Get(i, k), m.Get(j, k)
+		m.Set(i, k, v2)
+		m.Set(j, k, v1)
+	}
+	for k := 0; k < m.Rows; k++ {
+		v1, v2 := m.Get(k, i), m.Get(k, j)
+		m.Set(k, i, v2)
+		m.Set(k, j, v1)
+	}
+}
@@ -0,0 +1,82 @@
+package cholesky
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestLDLIndefinite(t *testing.T) {
+	mat := &linalg.Matrix{
+		Rows: 4,
+		Cols: 4,
+		Data: []float64{
+			0, 1, 2, -1,
+			1, 0, 3, 2,
+			2, 3, -1, 0.5,
+			-1, 2, 0.5, 4,
+		},
+	}
+	dec := DecomposeLDL(mat)
+	verifyLDL(t, mat, dec)
+
+	b := linalg.Vector{1, -2, 3, 0.5}
+	solution := dec.Solve(b)
+	product := linalg.Vector(mat.Mul(linalg.NewMatrixColumn(solution)).Data)
+	if solutionDiff(product, b) > 1e-10 {
+		t.Error("bad solution", solution, "gives product", product)
+	}
+
+	// Eigenvalues: -3.8201, -1.6381, 3.1218, 5.3365.
+	pos, neg, zero := dec.Inertia()
+	if pos != 2 || neg != 2 || zero != 0 {
+		t.Error("unexpected inertia:", pos, neg, zero)
+	}
+}
+
+func TestLDLZeroDiagonal(t *testing.T) {
+	// This matrix requires a 2x2 pivot.
+	mat := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{0, 3, 3, 0}}
+	dec := DecomposeLDL(mat)
+	verifyLDL(t, mat, dec)
+	solution := dec.Solve(linalg.Vector{6, 9})
+	if solutionDiff(solution, linalg.Vector{3, 2}) > 1e-12 {
+		t.Error("bad solution:", solution)
+	}
+}
+
+func TestLDLPositiveDefinite(t *testing.T) {
+	mat := randMatrix(10)
+	dec := DecomposeLDL(mat)
+	verifyLDL(t, mat, dec)
+	pos, neg, zero := dec.Inertia()
+	if pos != 10 || neg != 0 || zero != 0 {
+		t.Error("unexpected inertia:", pos, neg, zero)
+	}
+}
+
+func verifyLDL(t *testing.T, mat *linalg.Matrix, dec *LDL) {
+	product := dec.L.Mul(dec.D).Mul(dec.L.Transpose())
+	for i := 0; i < mat.Rows; i++ {
+		for j := 0; j < mat.Cols; j++ {
+			expected := mat.Get(dec.Perm[i], dec.Perm[j])
+			if math.Abs(product.Get(i, j)-expected) > 1e-10 {
+				t.Error("bad product", product, "for matrix", mat)
+				return
+			}
+		}
+		if dec.L.Get(i, i) != 1 {
+			t.Error("L does not have a unit diagonal:", dec.L)
+			return
+		}
+	}
+}
+
+func BenchmarkDecomposeLDL100x100(b *testing.B) {
+	matrix := randMatrix(100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DecomposeLDL(matrix)
+	}
+}
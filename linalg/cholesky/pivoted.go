@@ -0,0 +1,101 @@
+package cholesky
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+// Pivoted represents the pivoted Cholesky
+// decomposition P*A*P' = L*L' of a symmetric
+// positive semi-definite matrix A.
+type Pivoted struct {
+	// L is an NxR lower-trapezoidal matrix, where
+	// R is the numerical rank of A.
+	L *linalg.Matrix
+
+	// Perm is the symmetric permutation P, such that
+	// (P*A*P')[i][j] = A[Perm[i]][Perm[j]].
+	Perm ludecomp.Perm
+}
+
+// DecomposePivoted computes the pivoted Cholesky
+// decomposition of a symmetric positive semi-definite
+// matrix, choosing the largest remaining diagonal entry
+// as the pivot at each step.
+//
+// The decomposition stops once every remaining diagonal
+// entry is at most tol, which determines the rank.
+// If tol is not positive, a default tolerance based on
+// the size of the matrix and its diagonal is used.
+//
+// Like Decompose, this only accesses the upper
+// triangular portion of the given matrix.
+func DecomposePivoted(matrix *linalg.Matrix, tol float64) *Pivoted {
+	if !matrix.Square() {
+		panic("dimension mismatch")
+	}
+	n := matrix.Rows
+	a := symmetricCopy(matrix)
+	lower := linalg.NewMatrix(n, n)
+	perm := ludecomp.IdentityPerm(n)
+
+	if tol <= 0 {
+		var maxDiag float64
+		for i := 0; i < n; i++ {
+			maxDiag = math.Max(maxDiag, math.Abs(a.Get(i, i)))
+		}
+		epsilon := math.Nextafter(1, 2) - 1
+		tol = float64(n) * epsilon * maxDiag
+	}
+
+	rank := n
+	for k := 0; k < n; k++ {
+		pivot := k
+		for i := k + 1; i < n; i++ {
+			if a.Get(i, i) > a.Get(pivot, pivot) {
+				pivot = i
+			}
+		}
+		if !(a.Get(pivot, pivot) > tol) {
+			rank = k
+			break
+		}
+		if pivot != k {
+			swapSymmetric(a, k, pivot)
+			for j := 0; j < k; j++ {
+				v1, v2 := lower.Get(k, j), lower.Get(pivot, j)
+				lower.Set(k, j, v2)
+				lower.Set(pivot, j, v1)
+			}
+			perm.Swap(k, pivot)
+		}
+
+		diag := math.Sqrt(a.Get(k, k))
+		lower.Set(k, k, diag)
+		for i := k + 1; i < n; i++ {
+			lower.Set(i, k, a.Get(i, k)/diag)
+		}
+		for i := k + 1; i < n; i++ {
+			scale := lower.Get(i, k)
+			for j := k + 1; j < n; j++ {
+				a.Set(i, j, a.Get(i, j)-scale*lower.Get(j, k))
+			}
+		}
+	}
+
+	res := &Pivoted{L: linalg.NewMatrix(n, rank), Perm: perm}
+	for i := 0; i < n; i++ {
+		for j := 0; j < rank && j <= i; j++ {
+			res.L.Set(i, j, lower.Get(i, j))
+		}
+	}
+	return res
+}
+
+// Rank returns the numerical rank of the
+// decomposed matrix.
+func (p *Pivoted) Rank() int {
+	return p.L.Cols
+}
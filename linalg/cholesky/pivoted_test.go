@@ -0,0 +1,56 @@
+package cholesky
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestPivotedSemidefinite(t *testing.T) {
+	// mat = v1*v1' + v2*v2', which has rank 2.
+	v1 := linalg.Vector{1, 2, 0, -1}
+	v2 := linalg.Vector{0.5, -1, 3, 1}
+	mat := linalg.NewMatrix(4, 4)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			mat.Set(i, j, v1[i]*v1[j]+v2[i]*v2[j])
+		}
+	}
+
+	dec := DecomposePivoted(mat, 0)
+	if dec.Rank() != 2 {
+		t.Fatal("unexpected rank:", dec.Rank())
+	}
+	verifyPivoted(t, mat, dec)
+}
+
+func TestPivotedDefinite(t *testing.T) {
+	mat := randMatrix(8)
+	dec := DecomposePivoted(mat, 0)
+	if dec.Rank() != 8 {
+		t.Fatal("unexpected rank:", dec.Rank())
+	}
+	verifyPivoted(t, mat, dec)
+
+	// The pivots should be in decreasing order.
+	for i := 1; i < 8; i++ {
+		if dec.L.Get(i, i) > dec.L.Get(i-1, i-1) {
+			t.Error("pivots are not decreasing:", dec.L)
+			break
+		}
+	}
+}
+
+func verifyPivoted(t *testing.T, mat *linalg.Matrix, dec *Pivoted) {
+	product := dec.L.Mul(dec.L.Transpose())
+	for i := 0; i < mat.Rows; i++ {
+		for j := 0; j < mat.Cols; j++ {
+			expected := mat.Get(dec.Perm[i], dec.Perm[j])
+			if math.Abs(product.Get(i, j)-expected) > 1e-10 {
+				t.Error("bad product", product, "for matrix", mat)
+				return
+			}
+		}
+	}
+}
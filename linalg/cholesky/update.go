@@ -0,0 +1,69 @@
+package cholesky
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+var ErrNotPositiveDefinite = errors.New("matrix is not positive definite")
+
+// Update modifies c in place so that it represents
+// the decomposition of A + x*x', where A is the matrix
+// that c previously represented.
+//
+// This takes O(N^2) time, as opposed to the O(N^3)
+// time it would take to decompose A + x*x' directly.
+func (c *Cholesky) Update(x linalg.Vector) {
+	if len(x) != c.size {
+		panic("dimension mismatch")
+	}
+	c.rotate(c.lower, x.Copy(), 1)
+}
+
+// Downdate is like Update, except that it computes
+// the decomposition of A - x*x'.
+//
+// If A - x*x' is not positive definite, then c is
+// left unchanged and ErrNotPositiveDefinite is
+// returned.
+func (c *Cholesky) Downdate(x linalg.Vector) error {
+	if len(x) != c.size {
+		panic("dimension mismatch")
+	}
+	lower := make([]float64, len(c.lower))
+	copy(lower, c.lower)
+	if !c.rotate(lower, x.Copy(), -1) {
+		return ErrNotPositiveDefinite
+	}
+	c.lower = lower
+	return nil
+}
+
+// rotate applies a sequence of rotations (sign=1)
+// or hyperbolic rotations (sign=-1) to the packed
+// lower-triangular matrix lower, modifying x in the
+// process.
+// It returns false if a diagonal entry would become
+// non-positive.
+func (c *Cholesky) rotate(lower []float64, x linalg.Vector, sign float64) bool {
+	for k := 0; k < c.size; k++ {
+		diagIdx := k + (k*(k+1))/2
+		diag := lower[diagIdx]
+		squared := diag*diag + sign*x[k]*x[k]
+		if !(squared > 0) {
+			return false
+		}
+		newDiag := math.Sqrt(squared)
+		cos := newDiag / diag
+		sin := x[k] / diag
+		lower[diagIdx] = newDiag
+		for i := k + 1; i < c.size; i++ {
+			idx := k + (i*(i+1))/2
+			lower[idx] = (lower[idx] + sign*sin*x[i]) / cos
+			x[i] = cos*x[i] - sin*lower[idx]
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package cholesky
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestUpdate(t *testing.T) {
+	mat := randMatrix(6)
+	x := randVec(6)
+	dec := Decompose(mat)
+	dec.Update(x)
+
+	updated := mat.Copy()
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 6; j++ {
+			updated.Set(i, j, updated.Get(i, j)+x[i]*x[j])
+		}
+	}
+	verifyFactor(t, updated, dec)
+
+	if err := dec.Downdate(x); err != nil {
+		t.Fatal(err)
+	}
+	verifyFactor(t, mat, dec)
+}
+
+func TestDowndateIndefinite(t *testing.T) {
+	mat := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{1, 0, 0, 1}}
+	dec := Decompose(mat)
+	if err := dec.Downdate(linalg.Vector{1, 1}); err != ErrNotPositiveDefinite {
+		t.Error("expected ErrNotPositiveDefinite but got", err)
+	}
+	verifyFactor(t, mat, dec)
+}
+
+func BenchmarkUpdate200x200(b *testing.B) {
+	dec := Decompose(randMatrix(200))
+	x := randVec(200)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dec.Update(x)
+	}
+}
+
+func verifyFactor(t *testing.T, mat *linalg.Matrix, dec *Cholesky) {
+	for i := 0; i < mat.Rows; i++ {
+		for j := 0; j < mat.Cols; j++ {
+			var sum float64
+			for k := 0; k < dec.Size(); k++ {
+				sum += dec.Get(i, k) * dec.Get(j, k)
+			}
+			if math.Abs(sum-mat.Get(i, j)) > 1e-8*math.Max(1, math.Abs(mat.Get(i, j))) {
+				t.Error("entry", i, j, "should be", mat.Get(i, j), "but got", sum)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package linalg
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadCSV reads a matrix from comma-separated values.
+// Each record becomes a row of the matrix, and every
+// record must have the same number of fields.
+func ReadCSV(r io.Reader) (*Matrix, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return NewMatrix(0, 0), nil
+	}
+	res := NewMatrix(len(records), len(records[0]))
+	for i, record := range records {
+		for j, field := range record {
+			val, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return nil, err
+			}
+			res.Set(i, j, val)
+		}
+	}
+	return res, nil
+}
+
+// WriteCSV writes m as comma-separated values,
+// one record per row.
+// The values are written with enough precision
+// to be read back exactly.
+func WriteCSV(w io.Writer, m *Matrix) error {
+	writer := csv.NewWriter(w)
+	record := make([]string, m.Cols)
+	for i := 0; i < m.Rows; i++ {
+		for j := range record {
+			record[j] = formatExact(m.Get(i, j))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,33 @@
+package linalg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, encodingTestMatrix); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !matricesIdentical(decoded, encodingTestMatrix) {
+		t.Error("expected", encodingTestMatrix, "but got", decoded)
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	inputs := []string{
+		"1,2\n3\n",
+		"1,x\n",
+	}
+	for _, input := range inputs {
+		if _, err := ReadCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
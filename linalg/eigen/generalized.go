@@ -0,0 +1,98 @@
+package eigen
+
+import (
+	"sort"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/cholesky"
+)
+
+// SymmetricGeneralized solves the generalized
+// eigenproblem a*x = v*b*x, where a is symmetric and b
+// is symmetric positive-definite.
+//
+// It reduces the problem to a standard symmetric one
+// using the Cholesky decomposition of b.
+// The eigenvalues are sorted in ascending order, and
+// the eigenvectors are b-orthonormal, meaning that
+// x'*b*y is 1 when x = y and 0 otherwise.
+//
+// If b is not positive definite, then
+// cholesky.ErrNotPositiveDefinite is returned.
+func SymmetricGeneralized(a, b *linalg.Matrix) ([]float64, []linalg.Vector, error) {
+	if !a.Square() || !b.Square() || a.Rows != b.Rows {
+		panic("dimension mismatch")
+	}
+	chol, err := cholesky.DecomposeChecked(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Compute inv(L)*a*inv(L') as inv(L)*(inv(L)*a)',
+	// which works because a is symmetric.
+	n := a.Rows
+	halfReduced := linalg.NewMatrix(n, n)
+	for col := 0; col < n; col++ {
+		column := forwardSubstitute(chol, a.Col(col))
+		for row, x := range column {
+			halfReduced.Set(col, row, x)
+		}
+	}
+	reduced := linalg.NewMatrix(n, n)
+	for col := 0; col < n; col++ {
+		column := forwardSubstitute(chol, halfReduced.Col(col))
+		for row, x := range column {
+			reduced.Set(row, col, x)
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			mean := (reduced.Get(i, j) + reduced.Get(j, i)) / 2
+			reduced.Set(i, j, mean)
+			reduced.Set(j, i, mean)
+		}
+	}
+
+	vals, vecs := jacobiSymmetric(reduced)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return vals[order[i]] < vals[order[j]]
+	})
+
+	resVals := make([]float64, n)
+	resVecs := make([]linalg.Vector, n)
+	for i, idx := range order {
+		resVals[i] = vals[idx]
+		resVecs[i] = backSubstituteTranspose(chol, vecs.Col(idx))
+	}
+	return resVals, resVecs, nil
+}
+
+// forwardSubstitute solves L*x = b for x.
+func forwardSubstitute(c *cholesky.Cholesky, b linalg.Vector) linalg.Vector {
+	res := make(linalg.Vector, len(b))
+	for i := range res {
+		sum := b[i]
+		for j := 0; j < i; j++ {
+			sum -= c.Get(i, j) * res[j]
+		}
+		res[i] = sum / c.Get(i, i)
+	}
+	return res
+}
+
+// backSubstituteTranspose solves L'*x = b for x.
+func backSubstituteTranspose(c *cholesky.Cholesky, b linalg.Vector) linalg.Vector {
+	res := make(linalg.Vector, len(b))
+	for i := len(res) - 1; i >= 0; i-- {
+		sum := b[i]
+		for j := i + 1; j < len(res); j++ {
+			sum -= c.Get(j, i) * res[j]
+		}
+		res[i] = sum / c.Get(i, i)
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+package eigen
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/cholesky"
+)
+
+func TestSymmetricGeneralized(t *testing.T) {
+	a := randomSymMatrix(8)
+	b := randomPosDefMatrix(8)
+	vals, vecs, err := SymmetricGeneralized(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, val := range vals {
+		if i > 0 && val < vals[i-1] {
+			t.Error("eigenvalues are not sorted:", vals)
+		}
+		vec := linalg.NewMatrixColumn(vecs[i])
+		lhs := a.Mul(vec).Col(0)
+		rhs := b.Mul(vec).Col(0).Scale(val)
+		if diff := lhs.Add(rhs.Scale(-1)).Mag(); diff > 1e-8 {
+			t.Error("eigenpair", i, "has residual", diff)
+		}
+	}
+	for i, v1 := range vecs {
+		bv1 := b.Mul(linalg.NewMatrixColumn(v1)).Col(0)
+		for j, v2 := range vecs {
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			if actual := bv1.Dot(v2); math.Abs(actual-expected) > 1e-8 {
+				t.Errorf("expected x%d'*B*x%d = %f but got %f", i, j, expected, actual)
+			}
+		}
+	}
+}
+
+func TestSymmetricGeneralizedIdentity(t *testing.T) {
+	solver := func(m *linalg.Matrix) ([]float64, []linalg.Vector) {
+		vals, vecs, err := SymmetricGeneralized(m, linalg.NewMatrixIdentity(m.Rows))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return vals, vecs
+	}
+	testEigenSolver(t, solver, symMat10x10, symMat10x10Eigs)
+}
+
+func TestSymmetricGeneralizedIndefinite(t *testing.T) {
+	b := linalg.NewMatrixIdentity(3)
+	b.Set(1, 1, -1)
+	_, _, err := SymmetricGeneralized(randomSymMatrix(3), b)
+	if err != cholesky.ErrNotPositiveDefinite {
+		t.Error("expected ErrNotPositiveDefinite but got", err)
+	}
+}
+
+func randomPosDefMatrix(size int) *linalg.Matrix {
+	m := linalg.NewMatrix(size, size)
+	for i := range m.Data {
+		m.Data[i] = rand.Float64()*2 - 1
+	}
+	res := m.Mul(m.Transpose())
+	for i := 0; i < size; i++ {
+		res.Set(i, i, res.Get(i, i)+0.1)
+	}
+	return res
+}
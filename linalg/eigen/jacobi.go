@@ -0,0 +1,80 @@
+package eigen
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+const jacobiMaxSweeps = 100
+
+// jacobiSymmetric computes the eigenvalues and
+// eigenvectors of a small symmetric matrix using the
+// cyclic Jacobi method.
+// The eigenvectors are the columns of the returned
+// matrix.
+func jacobiSymmetric(m *linalg.Matrix) ([]float64, *linalg.Matrix) {
+	a := m.Copy()
+	n := a.Rows
+	vecs := linalg.NewMatrixIdentity(n)
+
+	for sweep := 0; sweep < jacobiMaxSweeps; sweep++ {
+		var off, total float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				sq := a.Get(i, j) * a.Get(i, j)
+				total += sq
+				if i != j {
+					off += sq
+				}
+			}
+		}
+		if off <= 1e-30*total {
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				jacobiRotate(a, vecs, p, q)
+			}
+		}
+	}
+
+	vals := make([]float64, n)
+	for i := range vals {
+		vals[i] = a.Get(i, i)
+	}
+	return vals, vecs
+}
+
+// jacobiRotate applies a rotation which zeroes the
+// (p, q) entry of a, accumulating it into vecs.
+func jacobiRotate(a, vecs *linalg.Matrix, p, q int) {
+	apq := a.Get(p, q)
+	if apq == 0 {
+		return
+	}
+	theta := (a.Get(q, q) - a.Get(p, p)) / (2 * apq)
+	t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+	if theta < 0 {
+		t = -t
+	}
+	c := 1 / math.Sqrt(t*t+1)
+	s := t * c
+
+	n := a.Rows
+	for k := 0; k < n; k++ {
+		akp, akq := a.Get(k, p), a.Get(k, q)
+		a.Set(k, p, c*akp-s*akq)
+		a.Set(k, q, s*akp+c*akq)
+	}
+	for k := 0; k < n; k++ {
+		apk, aqk := a.Get(p, k), a.Get(q, k)
+		a.Set(p, k, c*apk-s*aqk)
+		a.Set(q, k, s*apk+c*aqk)
+	}
+	for k := 0; k < n; k++ {
+		vkp, vkq := vecs.Get(k, p), vecs.Get(k, q)
+		vecs.Set(k, p, c*vkp-s*vkq)
+		vecs.Set(k, q, s*vkp+c*vkq)
+	}
+}
@@ -0,0 +1,254 @@
+package eigen
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/unixpickle/num-analysis/conjgrad"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+const lanczosMaxRestarts = 1000
+
+var ErrNoConvergence = errors.New("eigenpairs did not converge")
+
+// Which specifies which end of the spectrum an
+// iterative eigensolver should look for.
+type Which int
+
+const (
+	// Largest selects the algebraically largest
+	// eigenvalues.
+	Largest Which = iota
+
+	// Smallest selects the algebraically smallest
+	// eigenvalues.
+	Smallest
+)
+
+// LanczosResult stores eigenpairs found by Lanczos.
+type LanczosResult struct {
+	// Values stores the eigenvalues, sorted from the
+	// requested end of the spectrum inward.
+	Values []float64
+
+	// Vectors stores the corresponding unit-length
+	// eigenvectors.
+	Vectors []linalg.Vector
+
+	// Residuals stores norm(Av-xv) for each pair,
+	// bounding the distance from each value to the
+	// nearest true eigenvalue.
+	Residuals []float64
+}
+
+// Lanczos approximates k eigenpairs at one end of the
+// spectrum of a symmetric operator using the
+// thick-restart Lanczos method.
+//
+// Unlike Symmetric, this only applies the operator to
+// vectors, so it works for large sparse operators.
+//
+// The search stops once every residual is at most
+// prec.
+// If this does not happen after many restarts, the
+// best approximations are returned along with
+// ErrNoConvergence.
+func Lanczos(t conjgrad.LinTran, k int, which Which, prec float64) (*LanczosResult, error) {
+	n := t.Dim()
+	if k <= 0 || k > n {
+		panic("invalid number of eigenpairs")
+	}
+	basisSize := 2*k + 10
+	if basisSize < 20 {
+		basisSize = 20
+	}
+	if basisSize > n {
+		basisSize = n
+	}
+
+	l := &lanczos{op: t, basisSize: basisSize}
+	l.basis = []linalg.Vector{l.orthogonalRandom()}
+	l.projected = linalg.NewMatrix(basisSize, basisSize)
+
+	var vals []float64
+	var coeffs *linalg.Matrix
+	for restart := 0; restart < lanczosMaxRestarts; restart++ {
+		l.extend()
+		vals, coeffs = l.ritz(which)
+
+		tol := prec
+		for _, x := range vals {
+			tol = math.Max(tol, 1e-13*math.Abs(x))
+		}
+		converged := true
+		for i := 0; i < k; i++ {
+			if l.ritzResidual(coeffs, i) > tol {
+				converged = false
+				break
+			}
+		}
+		if converged {
+			return l.result(vals, coeffs, k), nil
+		}
+
+		keep := k + (basisSize-k)/2
+		if keep >= basisSize {
+			keep = basisSize - 1
+		}
+		l.restart(vals, coeffs, keep)
+	}
+
+	return l.result(vals, coeffs, k), ErrNoConvergence
+}
+
+type lanczos struct {
+	op        conjgrad.LinTran
+	basisSize int
+
+	// basis stores orthonormal vectors spanning the
+	// search space.
+	basis []linalg.Vector
+
+	// projected is the operator projected onto the
+	// basis.
+	projected *linalg.Matrix
+
+	// residual is the unit vector orthogonal to the
+	// full basis, and residualNorm is its coefficient
+	// in the image of the last basis vector.
+	residual     linalg.Vector
+	residualNorm float64
+}
+
+// extend grows the basis to its full size, keeping it
+// orthonormal and updating the projected operator.
+func (l *lanczos) extend() {
+	for j := len(l.basis) - 1; j < l.basisSize; j++ {
+		w := l.op.Apply(l.basis[j])
+		for i := 0; i <= j; i++ {
+			dot := w.Dot(l.basis[i])
+			l.projected.Set(i, j, dot)
+			l.projected.Set(j, i, dot)
+		}
+		l.orthogonalize(w)
+		norm := w.Mag()
+		if j+1 == l.basisSize {
+			l.residualNorm = norm
+			if norm != 0 {
+				l.residual = w.Scale(1 / norm)
+			}
+			return
+		}
+		if norm == 0 {
+			// The basis spans an invariant subspace, so
+			// we continue with an unrelated vector.
+			l.basis = append(l.basis, l.orthogonalRandom())
+		} else {
+			l.basis = append(l.basis, w.Scale(1/norm))
+		}
+	}
+}
+
+// ritz computes the Ritz values and the coefficients
+// of the Ritz vectors in terms of the basis, sorted
+// from the requested end of the spectrum.
+func (l *lanczos) ritz(which Which) ([]float64, *linalg.Matrix) {
+	vals, vecs := jacobiSymmetric(l.projected)
+	order := make([]int, len(vals))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if which == Largest {
+			return vals[order[i]] > vals[order[j]]
+		}
+		return vals[order[i]] < vals[order[j]]
+	})
+	sortedVals := make([]float64, len(vals))
+	sortedVecs := linalg.NewMatrix(vecs.Rows, vecs.Cols)
+	for i, idx := range order {
+		sortedVals[i] = vals[idx]
+		for row := 0; row < vecs.Rows; row++ {
+			sortedVecs.Set(row, i, vecs.Get(row, idx))
+		}
+	}
+	return sortedVals, sortedVecs
+}
+
+// ritzResidual computes norm(Av-xv) for the i-th Ritz
+// pair without applying the operator.
+func (l *lanczos) ritzResidual(coeffs *linalg.Matrix, i int) float64 {
+	return math.Abs(l.residualNorm * coeffs.Get(l.basisSize-1, i))
+}
+
+// ritzVector computes the i-th Ritz vector.
+func (l *lanczos) ritzVector(coeffs *linalg.Matrix, i int) linalg.Vector {
+	res := make(linalg.Vector, l.op.Dim())
+	for j, v := range l.basis {
+		res.Add(v.Copy().Scale(coeffs.Get(j, i)))
+	}
+	return res
+}
+
+// restart replaces the basis with the first keep Ritz
+// vectors followed by the residual vector.
+func (l *lanczos) restart(vals []float64, coeffs *linalg.Matrix, keep int) {
+	newBasis := make([]linalg.Vector, keep, keep+1)
+	for i := range newBasis {
+		newBasis[i] = l.ritzVector(coeffs, i)
+	}
+	l.projected = linalg.NewMatrix(l.basisSize, l.basisSize)
+	for i := 0; i < keep; i++ {
+		l.projected.Set(i, i, vals[i])
+	}
+	l.basis = newBasis
+	if l.residualNorm == 0 {
+		l.basis = append(l.basis, l.orthogonalRandom())
+	} else {
+		l.basis = append(l.basis, l.residual)
+	}
+}
+
+func (l *lanczos) result(vals []float64, coeffs *linalg.Matrix, k int) *LanczosResult {
+	res := &LanczosResult{
+		Values:    vals[:k],
+		Vectors:   make([]linalg.Vector, k),
+		Residuals: make([]float64, k),
+	}
+	for i := 0; i < k; i++ {
+		vec := l.ritzVector(coeffs, i)
+		normalizeTwoNorm(vec)
+		res.Vectors[i] = vec
+		res.Residuals[i] = l.op.Apply(vec).Add(vec.Copy().Scale(-vals[i])).Mag()
+	}
+	return res
+}
+
+// orthogonalize projects the basis out of v twice,
+// which keeps the basis orthogonal to working
+// precision.
+func (l *lanczos) orthogonalize(v linalg.Vector) {
+	for pass := 0; pass < 2; pass++ {
+		for _, b := range l.basis {
+			v.Add(b.Copy().Scale(-b.Dot(v)))
+		}
+	}
+}
+
+// orthogonalRandom generates a random unit vector
+// which is orthogonal to the basis.
+func (l *lanczos) orthogonalRandom() linalg.Vector {
+	for {
+		v := make(linalg.Vector, l.op.Dim())
+		for i := range v {
+			v[i] = rand.Float64()*2 - 1
+		}
+		l.orthogonalize(v)
+		if norm := v.Mag(); norm > 1e-8 {
+			return v.Scale(1 / norm)
+		}
+	}
+}
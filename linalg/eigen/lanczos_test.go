@@ -0,0 +1,127 @@
+package eigen
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/conjgrad"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestLanczosLaplacian(t *testing.T) {
+	const size = 100
+	op := conjgrad.NewSparseMatrix(size)
+	for i := 0; i < size; i++ {
+		op.Set(i, i, 2)
+		if i > 0 {
+			op.Set(i, i-1, -1)
+			op.Set(i-1, i, -1)
+		}
+	}
+	eigs := make([]float64, size)
+	for i := range eigs {
+		eigs[i] = 2 - 2*math.Cos(float64(i+1)*math.Pi/(size+1))
+	}
+
+	res, err := Lanczos(op, 4, Smallest, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyLanczos(t, op, res, eigs[:4], 1e-9)
+
+	res, err = Lanczos(op, 4, Largest, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyLanczos(t, op, res, []float64{eigs[99], eigs[98], eigs[97], eigs[96]}, 1e-9)
+}
+
+func TestLanczosDense(t *testing.T) {
+	mat := randomSymMatrix(30)
+	op := conjgrad.MatLinTran{M: mat}
+	eigs, _ := jacobiSymmetric(mat)
+	sort.Float64s(eigs)
+
+	res, err := Lanczos(op, 5, Smallest, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyLanczos(t, op, res, eigs[:5], 1e-9)
+
+	// With a full basis, every eigenpair is found.
+	res, err = Lanczos(op, 30, Largest, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reversed := make([]float64, len(eigs))
+	for i, x := range eigs {
+		reversed[len(eigs)-(i+1)] = x
+	}
+	verifyLanczos(t, op, res, reversed, 1e-9)
+}
+
+func TestLanczosRepeated(t *testing.T) {
+	mat := linalg.NewMatrix(40, 40)
+	for i := 0; i < 40; i++ {
+		mat.Set(i, i, float64(i%4))
+	}
+	res, err := Lanczos(conjgrad.MatLinTran{M: mat}, 3, Largest, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyLanczos(t, conjgrad.MatLinTran{M: mat}, res, []float64{3, 3, 3}, 1e-9)
+}
+
+func TestJacobiSymmetric(t *testing.T) {
+	mat := randomSymMatrix(10)
+	vals, vecs := jacobiSymmetric(mat)
+	for i, val := range vals {
+		vec := vecs.Col(i)
+		diff := mat.Mul(linalg.NewMatrixColumn(vec)).Col(0).Add(vec.Copy().Scale(-val))
+		if diff.Mag() > 1e-10 {
+			t.Error("bad eigenpair", i, "with residual", diff.Mag())
+		}
+	}
+	product := vecs.Transpose().Mul(vecs)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			if math.Abs(product.Get(i, j)-expected) > 1e-10 {
+				t.Fatal("eigenvectors are not orthonormal")
+			}
+		}
+	}
+}
+
+func verifyLanczos(t *testing.T, op conjgrad.LinTran, res *LanczosResult,
+	expected []float64, prec float64) {
+	if len(res.Values) != len(expected) || len(res.Vectors) != len(expected) ||
+		len(res.Residuals) != len(expected) {
+		t.Fatal("unexpected result lengths")
+	}
+	for i, x := range expected {
+		if math.Abs(res.Values[i]-x) > 1e-7 {
+			t.Error("eigenvalue", i, "should be", x, "but got", res.Values[i])
+		}
+		vec := res.Vectors[i]
+		if math.Abs(vec.Mag()-1) > 1e-10 {
+			t.Error("eigenvector", i, "has magnitude", vec.Mag())
+		}
+		residual := op.Apply(vec).Add(vec.Copy().Scale(-res.Values[i])).Mag()
+		if math.Abs(residual-res.Residuals[i]) > 1e-12 || residual > 10*prec {
+			t.Error("eigenpair", i, "has residual", residual, "but reported",
+				res.Residuals[i])
+		}
+	}
+	for i := range res.Vectors {
+		for j := 0; j < i; j++ {
+			if dot := res.Vectors[i].Dot(res.Vectors[j]); math.Abs(dot) > 1e-8 {
+				t.Error("eigenvectors", i, "and", j, "are not orthogonal:", dot)
+			}
+		}
+	}
+}
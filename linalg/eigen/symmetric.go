@@ -1,6 +1,7 @@
 package eigen
 
 import (
+	"context"
 	"errors"
 	"math"
 	"math/rand"
@@ -128,6 +129,37 @@ func SymmetricPrecAsync(m *linalg.Matrix, p float64) *EigenChan {
 	}
 }
 
+// SymmetricContext is like Symmetric, but it stops
+// early if ctx is done.
+//
+// If ctx is done before every eigenpair is found, the
+// eigenpairs which were already found are returned
+// along with ctx.Err().
+func SymmetricContext(ctx context.Context, m *linalg.Matrix) ([]float64,
+	[]linalg.Vector, error) {
+	return SymmetricPrecContext(ctx, m, 0)
+}
+
+// SymmetricPrecContext is like SymmetricPrec, but it
+// uses ctx instead of a timeout to decide when to give
+// up.
+func SymmetricPrecContext(ctx context.Context, m *linalg.Matrix,
+	p float64) ([]float64, []linalg.Vector, error) {
+	iterator := symmetricIterator{
+		matrix:       m,
+		cancelChan:   ctx.Done(),
+		eigenVectors: make([]linalg.Vector, 0, m.Rows),
+		eigenValues:  make([]float64, 0, m.Rows),
+		precision:    p,
+	}
+	for i := 0; i < m.Rows; i++ {
+		if !iterator.findNextVector() {
+			return iterator.eigenValues, iterator.eigenVectors, ctx.Err()
+		}
+	}
+	return iterator.eigenValues, iterator.eigenVectors, nil
+}
+
 // SymmetricFixedTime is like Symmetric, but it only
 // spends a certain amount of time converging to the
 // answer.
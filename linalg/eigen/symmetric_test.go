@@ -1,6 +1,7 @@
 package eigen
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 	"time"
@@ -40,6 +41,11 @@ var symMat10x10 = &linalg.Matrix{
 	},
 }
 
+var symMat10x10Eigs = []float64{-3.53320764624989e+00,
+	1.94571466978943e-02, 3.94135968791024e-02, 2.79652524908013e-01,
+	3.83072877722642e-01, 6.66544542615382e-01, 1.16866047971769e+00,
+	1.83799425365499e+00, 2.46391983763316e+00, 2.39701303840477e+01}
+
 func symmetricEigenSolver(m *linalg.Matrix) ([]float64, []linalg.Vector) {
 	rand.Seed(time.Now().UnixNano())
 	return Symmetric(m)
@@ -56,6 +62,12 @@ func symmetricTimeEigenSolver(m *linalg.Matrix) ([]float64, []linalg.Vector) {
 	return SymmetricFixedTime(m, time.Millisecond*30)
 }
 
+func symmetricContextEigenSolver(m *linalg.Matrix) ([]float64, []linalg.Vector) {
+	rand.Seed(time.Now().UnixNano())
+	a, b, _ := SymmetricContext(context.Background(), m)
+	return a, b
+}
+
 func TestSymmetricBasic(t *testing.T) {
 	mat := &linalg.Matrix{
 		Rows: 3,
@@ -89,13 +101,11 @@ func TestSymmetricNullspace(t *testing.T) {
 }
 
 func TestSymmetric10x10(t *testing.T) {
-	eigs := []float64{-3.53320764624989e+00,
-		1.94571466978943e-02, 3.94135968791024e-02, 2.79652524908013e-01,
-		3.83072877722642e-01, 6.66544542615382e-01, 1.16866047971769e+00,
-		1.83799425365499e+00, 2.46391983763316e+00, 2.39701303840477e+01}
+	eigs := symMat10x10Eigs
 	testEigenSolver(t, symmetricEigenSolver, symMat10x10, eigs)
 	testEigenSolver(t, symmetricPrecEigenSolver, symMat10x10, eigs)
 	testEigenSolver(t, symmetricTimeEigenSolver, symMat10x10, eigs)
+	testEigenSolver(t, symmetricContextEigenSolver, symMat10x10, eigs)
 }
 
 func TestSymmetricRepeatedEig(t *testing.T) {
@@ -159,6 +169,23 @@ func TestSymmetricAsyncCancel(t *testing.T) {
 	}
 }
 
+func TestSymmetricContextCancel(t *testing.T) {
+	mat := randomSymMatrix(100)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*30)
+	defer cancel()
+	start := time.Now()
+	vals, vecs, err := SymmetricContext(ctx, mat)
+	if err != context.DeadlineExceeded {
+		t.Error("expected DeadlineExceeded but got", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("solver was not cancelled")
+	}
+	if len(vals) != len(vecs) || len(vals) == mat.Rows {
+		t.Error("unexpected number of eigenpairs:", len(vals), len(vecs))
+	}
+}
+
 func BenchmarkSymmetric10x10(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		symmetricEigenSolver(symMat10x10)
@@ -0,0 +1,193 @@
+package linalg
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// ErrBinaryFormat is returned when decoding binary data
+// which was not produced by MarshalBinary.
+var ErrBinaryFormat = errors.New("invalid binary data")
+
+// MarshalJSON encodes v as a JSON array.
+// Non-finite entries are encoded as the strings
+// "NaN", "+Inf", and "-Inf".
+func (v Vector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonFloats(v))
+}
+
+// UnmarshalJSON decodes a JSON array produced by
+// MarshalJSON.
+func (v *Vector) UnmarshalJSON(data []byte) error {
+	var floats []jsonFloat
+	if err := json.Unmarshal(data, &floats); err != nil {
+		return err
+	}
+	*v = make(Vector, len(floats))
+	for i, x := range floats {
+		(*v)[i] = float64(x)
+	}
+	return nil
+}
+
+// MarshalBinary encodes v in a compact binary format
+// consisting of the length of v followed by each of its
+// entries, all in little-endian order.
+func (v Vector) MarshalBinary() ([]byte, error) {
+	res := make([]byte, 8+8*len(v))
+	binary.LittleEndian.PutUint64(res, uint64(len(v)))
+	putFloats(res[8:], v)
+	return res, nil
+}
+
+// UnmarshalBinary decodes data produced by
+// MarshalBinary.
+func (v *Vector) UnmarshalBinary(data []byte) error {
+	if len(data) < 8 {
+		return ErrBinaryFormat
+	}
+	size := binary.LittleEndian.Uint64(data)
+	if (len(data)-8)%8 != 0 || size != uint64(len(data)-8)/8 {
+		return ErrBinaryFormat
+	}
+	*v = make(Vector, size)
+	getFloats(*v, data[8:])
+	return nil
+}
+
+type jsonMatrix struct {
+	Rows int
+	Cols int
+	Data []jsonFloat
+}
+
+// MarshalJSON encodes m as a JSON object with the
+// fields Rows, Cols, and Data.
+// Non-finite entries are encoded like they are for
+// Vector.MarshalJSON.
+func (m *Matrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMatrix{
+		Rows: m.Rows,
+		Cols: m.Cols,
+		Data: jsonFloats(m.Data),
+	})
+}
+
+// UnmarshalJSON decodes a JSON object produced by
+// MarshalJSON, verifying that the dimensions of the
+// matrix agree with its data.
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var obj jsonMatrix
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if obj.Rows < 0 || obj.Cols < 0 || !dimensionsMatch(uint64(obj.Rows),
+		uint64(obj.Cols), uint64(len(obj.Data))) {
+		return errors.New("matrix dimensions do not match data")
+	}
+	m.Rows = obj.Rows
+	m.Cols = obj.Cols
+	m.Data = make([]float64, len(obj.Data))
+	for i, x := range obj.Data {
+		m.Data[i] = float64(x)
+	}
+	return nil
+}
+
+// MarshalBinary encodes m in a compact binary format
+// consisting of the row count, the column count, and
+// then m.Data, all in little-endian order.
+func (m *Matrix) MarshalBinary() ([]byte, error) {
+	res := make([]byte, 16+8*len(m.Data))
+	binary.LittleEndian.PutUint64(res, uint64(m.Rows))
+	binary.LittleEndian.PutUint64(res[8:], uint64(m.Cols))
+	putFloats(res[16:], m.Data)
+	return res, nil
+}
+
+// UnmarshalBinary decodes data produced by
+// MarshalBinary.
+func (m *Matrix) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 {
+		return ErrBinaryFormat
+	}
+	rows := binary.LittleEndian.Uint64(data)
+	cols := binary.LittleEndian.Uint64(data[8:])
+	count := uint64(len(data)-16) / 8
+	if (len(data)-16)%8 != 0 || !dimensionsMatch(rows, cols, count) {
+		return ErrBinaryFormat
+	}
+	m.Rows = int(rows)
+	m.Cols = int(cols)
+	m.Data = make([]float64, count)
+	getFloats(m.Data, data[16:])
+	return nil
+}
+
+// dimensionsMatch checks that rows*cols == count
+// without overflowing, and that both dimensions fit
+// in an int.
+func dimensionsMatch(rows, cols, count uint64) bool {
+	if rows > math.MaxInt32 || cols > math.MaxInt32 {
+		return false
+	}
+	return rows*cols == count
+}
+
+func putFloats(dest []byte, values []float64) {
+	for i, x := range values {
+		binary.LittleEndian.PutUint64(dest[i*8:], math.Float64bits(x))
+	}
+}
+
+func getFloats(dest []float64, source []byte) {
+	for i := range dest {
+		dest[i] = math.Float64frombits(binary.LittleEndian.Uint64(source[i*8:]))
+	}
+}
+
+// jsonFloat is a float64 which can represent
+// non-finite values in JSON.
+type jsonFloat float64
+
+func jsonFloats(values []float64) []jsonFloat {
+	res := make([]jsonFloat, len(values))
+	for i, x := range values {
+		res[i] = jsonFloat(x)
+	}
+	return res
+}
+
+func (j jsonFloat) MarshalJSON() ([]byte, error) {
+	x := float64(j)
+	switch {
+	case math.IsNaN(x):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(x, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(x, -1):
+		return []byte(`"-Inf"`), nil
+	}
+	return []byte(formatExact(x)), nil
+}
+
+func (j *jsonFloat) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		x, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return err
+		}
+		*j = jsonFloat(x)
+		return nil
+	}
+	var x float64
+	if err := json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+	*j = jsonFloat(x)
+	return nil
+}
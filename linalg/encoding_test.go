@@ -0,0 +1,131 @@
+package linalg
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+var encodingTestMatrix = &Matrix{
+	Rows: 2,
+	Cols: 3,
+	Data: []float64{
+		1, -2.5, 1.0 / 3.0,
+		0, 1e-300, math.Inf(1),
+	},
+}
+
+func TestMatrixJSON(t *testing.T) {
+	data, err := json.Marshal(encodingTestMatrix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Matrix
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !matricesIdentical(&decoded, encodingTestMatrix) {
+		t.Error("expected", encodingTestMatrix, "but got", &decoded)
+	}
+
+	bad := []byte(`{"Rows":2,"Cols":2,"Data":[1,2,3]}`)
+	if err := json.Unmarshal(bad, &decoded); err == nil {
+		t.Error("expected error for mismatched dimensions")
+	}
+}
+
+func TestVectorJSON(t *testing.T) {
+	vec := Vector{1, math.NaN(), math.Inf(-1), 0.1}
+	data, err := json.Marshal(vec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Vector
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsIdentical(decoded, vec) {
+		t.Error("expected", vec, "but got", decoded)
+	}
+}
+
+func TestMatrixBinary(t *testing.T) {
+	data, err := encodingTestMatrix.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Matrix
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !matricesIdentical(&decoded, encodingTestMatrix) {
+		t.Error("expected", encodingTestMatrix, "but got", &decoded)
+	}
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Error("expected error for truncated data")
+	}
+}
+
+func TestVectorBinary(t *testing.T) {
+	vec := Vector{1, math.NaN(), -7, math.SmallestNonzeroFloat64}
+	data, err := vec.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Vector
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !vectorsIdentical(decoded, vec) {
+		t.Error("expected", vec, "but got", decoded)
+	}
+	if err := decoded.UnmarshalBinary(data[:4]); err == nil {
+		t.Error("expected error for truncated data")
+	}
+}
+
+func TestBinaryMalformedHeader(t *testing.T) {
+	// A length which overflows when multiplied by 8.
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, 1<<61)
+	var vec Vector
+	if err := vec.UnmarshalBinary(data); err != ErrBinaryFormat {
+		t.Error("expected ErrBinaryFormat but got", err)
+	}
+
+	// Dimensions which do not fit in an int.
+	headers := [][2]uint64{{1 << 63, 0}, {0, 1 << 63}, {1 << 32, 1 << 32}, {1 << 62, 4}}
+	for _, header := range headers {
+		data := make([]byte, 16)
+		binary.LittleEndian.PutUint64(data, header[0])
+		binary.LittleEndian.PutUint64(data[8:], header[1])
+		var m Matrix
+		if err := m.UnmarshalBinary(data); err != ErrBinaryFormat {
+			t.Error("expected ErrBinaryFormat for", header, "but got", err)
+		}
+	}
+
+	var m Matrix
+	if err := json.Unmarshal([]byte(`{"Rows":4294967296,"Cols":4294967296,"Data":[]}`),
+		&m); err == nil {
+		t.Error("expected error for overflowing JSON dimensions")
+	}
+}
+
+func matricesIdentical(m1, m2 *Matrix) bool {
+	return m1.Rows == m2.Rows && m1.Cols == m2.Cols && vectorsIdentical(m1.Data, m2.Data)
+}
+
+func vectorsIdentical(v1, v2 Vector) bool {
+	if len(v1) != len(v2) {
+		return false
+	}
+	for i, x := range v1 {
+		if math.Float64bits(x) != math.Float64bits(v2[i]) &&
+			!(math.IsNaN(x) && math.IsNaN(v2[i])) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package leastsquares
+
+import (
+	"github.com/unixpickle/num-analysis/kahan"
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/qrdecomp"
+)
+
+// A ConstrainedSolver solves equality-constrained
+// least-squares problems, minimizing ||A*x-b|| for x
+// subject to C*x = d.
+//
+// It uses the null-space method: a QR decomposition of
+// C' splits x into a part which is fixed by the
+// constraints and a part which is free to minimize
+// the residual.
+type ConstrainedSolver struct {
+	a *linalg.Matrix
+	c *linalg.Matrix
+
+	// constraintR is the top square part of R for C'.
+	constraintR *linalg.Matrix
+
+	// rangeBasis and nullBasis are the first P and the
+	// last N-P columns of Q for C'.
+	rangeBasis *linalg.Matrix
+	nullBasis  *linalg.Matrix
+
+	reduced *Solver
+}
+
+// NewConstrainedSolver creates a ConstrainedSolver
+// for the MxN matrix a and the PxN matrix c.
+//
+// The rows of c must be independent, and a must have
+// independent columns when restricted to the null
+// space of c.
+func NewConstrainedSolver(a, c *linalg.Matrix) *ConstrainedSolver {
+	if a.Cols != c.Cols {
+		panic("dimension mismatch")
+	}
+	if c.Rows > c.Cols {
+		panic("rows of c cannot be independent")
+	}
+	n, p := c.Cols, c.Rows
+	qr := qrdecomp.NewQR(c.Transpose())
+
+	res := &ConstrainedSolver{
+		a:           a,
+		c:           c,
+		constraintR: linalg.NewMatrix(p, p),
+		rangeBasis:  linalg.NewMatrix(n, p),
+		nullBasis:   linalg.NewMatrix(n, n-p),
+	}
+	for i := 0; i < p; i++ {
+		for j := 0; j < p; j++ {
+			res.constraintR.Set(i, j, qr.R.Get(i, j))
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if j < p {
+				res.rangeBasis.Set(i, j, qr.Q.Get(i, j))
+			} else {
+				res.nullBasis.Set(i, j-p, qr.Q.Get(i, j))
+			}
+		}
+	}
+	if n > p {
+		res.reduced = NewSolver(a.Mul(res.nullBasis))
+	}
+	return res
+}
+
+// Solve finds the x which minimizes ||A*x-b|| among all
+// of the solutions to C*x = d.
+func (s *ConstrainedSolver) Solve(b, d linalg.Vector) linalg.Vector {
+	if len(b) != s.a.Rows || len(d) != s.c.Rows {
+		panic("dimension mismatch")
+	}
+
+	// Since C' = Q1*R, the constraint is R'*Q1'*x = d.
+	fixed := s.forwardSubstituteTranspose(d)
+	x := linalg.Vector(s.rangeBasis.Mul(linalg.NewMatrixColumn(fixed)).Data)
+	if s.reduced == nil {
+		return x
+	}
+
+	ax := linalg.Vector(s.a.Mul(linalg.NewMatrixColumn(x)).Data)
+	free := s.reduced.Solve(ax.Scale(-1).Add(b))
+	return x.Add(linalg.Vector(s.nullBasis.Mul(linalg.NewMatrixColumn(free)).Data))
+}
+
+func (s *ConstrainedSolver) forwardSubstituteTranspose(d linalg.Vector) linalg.Vector {
+	r := s.constraintR
+	res := make(linalg.Vector, len(d))
+	for i := range res {
+		sum := kahan.NewSummer64()
+		sum.Add(d[i])
+		for j := 0; j < i; j++ {
+			sum.Add(-r.Get(j, i) * res[j])
+		}
+		res[i] = sum.Sum() / r.Get(i, i)
+	}
+	return res
+}
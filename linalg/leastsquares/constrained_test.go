@@ -0,0 +1,57 @@
+package leastsquares
+
+import (
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+func TestConstrainedSolver(t *testing.T) {
+	a := randomMatrix(8, 5)
+	c := randomMatrix(2, 5)
+	b := linalg.RandVector(8)
+	d := linalg.RandVector(2)
+
+	actual := NewConstrainedSolver(a, c).Solve(b, d)
+
+	// Solve the KKT system
+	// [A'A C'; C 0] * [x; mu] = [A'b; d].
+	kkt := linalg.NewMatrix(7, 7)
+	normal := a.Transpose().Mul(a)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			kkt.Set(i, j, normal.Get(i, j))
+		}
+		for j := 0; j < 2; j++ {
+			kkt.Set(i, 5+j, c.Get(j, i))
+			kkt.Set(5+j, i, c.Get(j, i))
+		}
+	}
+	rhs := make(linalg.Vector, 7)
+	copy(rhs, a.Transpose().Mul(linalg.NewMatrixColumn(b)).Data)
+	copy(rhs[5:], d)
+	expected := ludecomp.Decompose(kkt).Solve(rhs)[:5]
+
+	if vectorDiff(actual, expected) > 1e-8 {
+		t.Error("expected", expected, "but got", actual)
+	}
+	if diff := vectorDiff(c.Mul(linalg.NewMatrixColumn(actual)).Data, d); diff > 1e-8 {
+		t.Error("constraints violated by", diff)
+	}
+}
+
+func TestConstrainedSolverDetermined(t *testing.T) {
+	// When there are as many constraints as unknowns,
+	// the constraints alone determine x.
+	a := randomMatrix(5, 3)
+	c := randomMatrix(3, 3)
+	b := linalg.RandVector(5)
+	d := linalg.RandVector(3)
+
+	actual := NewConstrainedSolver(a, c).Solve(b, d)
+	expected := ludecomp.Decompose(c).Solve(d)
+	if vectorDiff(actual, expected) > 1e-8 {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
@@ -0,0 +1,160 @@
+package leastsquares
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/kahan"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A RidgeSolver solves Tikhonov-regularized
+// least-squares problems, minimizing
+// ||A*x-b||^2 + lambda*||x||^2 for x given a vector b.
+type RidgeSolver struct {
+	solver *Solver
+	rows   int
+	lambda float64
+}
+
+// NewRidgeSolver creates a RidgeSolver for the matrix
+// m and the regularization strength lambda.
+//
+// Unlike NewSolver, this works for any m as long as
+// lambda is positive.
+// A lambda of 0 gives plain least squares, which
+// requires m to have linearly independent columns.
+// Negative values of lambda cause a panic.
+// It decomposes m stacked on top of sqrt(lambda)*I, so
+// the normal equations are never formed.
+func NewRidgeSolver(m *linalg.Matrix, lambda float64) *RidgeSolver {
+	if !(lambda >= 0) {
+		panic("lambda must be non-negative")
+	}
+	stacked := linalg.NewMatrix(m.Rows+m.Cols, m.Cols)
+	copy(stacked.Data, m.Data)
+	for i := 0; i < m.Cols; i++ {
+		stacked.Set(m.Rows+i, i, math.Sqrt(lambda))
+	}
+	return &RidgeSolver{
+		solver: NewSolver(stacked),
+		rows:   m.Rows,
+		lambda: lambda,
+	}
+}
+
+// Lambda returns the regularization strength.
+func (r *RidgeSolver) Lambda() float64 {
+	return r.lambda
+}
+
+// Solve finds the x which minimizes the regularized
+// squared error for the given b.
+func (r *RidgeSolver) Solve(b linalg.Vector) linalg.Vector {
+	if len(b) != r.rows {
+		panic("dimension mismatch")
+	}
+	padded := make(linalg.Vector, r.solver.orthogonal.Cols)
+	copy(padded, b)
+	return r.solver.Solve(padded)
+}
+
+// hatTrace computes the trace of the influence matrix
+// A*(A'*A+lambda*I)^-1*A', which is the number of
+// effective parameters in the fit.
+//
+// If the stacked matrix is Q*R, then the influence
+// matrix is Q1*Q1', where Q1 is the top part of Q.
+func (r *RidgeSolver) hatTrace() float64 {
+	sum := kahan.NewSummer64()
+	q := r.solver.orthogonal
+	for i := 0; i < q.Rows; i++ {
+		for j := 0; j < r.rows; j++ {
+			x := q.Get(i, j)
+			sum.Add(x * x)
+		}
+	}
+	return sum.Sum()
+}
+
+// RidgeGCV chooses a regularization strength for m and
+// b by generalized cross-validation.
+//
+// Each candidate lambda is scored by
+// n*||A*x-b||^2 / (n - trace(H))^2, where H is the
+// influence matrix, and the best candidate is returned
+// along with every score.
+func RidgeGCV(m *linalg.Matrix, b linalg.Vector, lambdas []float64) (best float64,
+	scores []float64) {
+	if len(lambdas) == 0 {
+		panic("no candidate lambdas")
+	}
+	n := float64(m.Rows)
+	scores = make([]float64, len(lambdas))
+	var bestScore float64
+	for i, lambda := range lambdas {
+		solver := NewRidgeSolver(m, lambda)
+		x := solver.Solve(b)
+		residual := residualNorm(m, x, b)
+		denom := n - solver.hatTrace()
+		scores[i] = n * residual * residual / (denom * denom)
+		if i == 0 || scores[i] < bestScore {
+			best = lambda
+			bestScore = scores[i]
+		}
+	}
+	return
+}
+
+// RidgeLCurve chooses a regularization strength for m
+// and b by finding the corner of the L-curve, which
+// plots log||A*x-b|| against log||x|| as lambda
+// varies.
+//
+// The candidates must be in ascending order, and there
+// must be at least three of them.
+// The corner is the candidate where the discrete
+// curvature of the L-curve is greatest.
+func RidgeLCurve(m *linalg.Matrix, b linalg.Vector, lambdas []float64) float64 {
+	if len(lambdas) < 3 {
+		panic("need at least three candidate lambdas")
+	}
+	points := make([][2]float64, len(lambdas))
+	for i, lambda := range lambdas {
+		x := NewRidgeSolver(m, lambda).Solve(b)
+		points[i] = [2]float64{
+			math.Log(residualNorm(m, x, b)),
+			math.Log(x.Mag()),
+		}
+	}
+
+	best := lambdas[1]
+	bestCurvature := math.Inf(-1)
+	for i := 1; i+1 < len(points); i++ {
+		if c := mengerCurvature(points[i-1], points[i], points[i+1]); c > bestCurvature {
+			bestCurvature = c
+			best = lambdas[i]
+		}
+	}
+	return best
+}
+
+func residualNorm(m *linalg.Matrix, x, b linalg.Vector) float64 {
+	product := linalg.Vector(m.Mul(linalg.NewMatrixColumn(x)).Data)
+	return product.Scale(-1).Add(b).Mag()
+}
+
+// mengerCurvature computes the signed curvature of the
+// circle through three points.
+// The sign is positive when the points turn counter
+// clockwise, as they do near the corner of an L-curve
+// traversed with increasing lambda.
+func mengerCurvature(p1, p2, p3 [2]float64) float64 {
+	cross := (p2[0]-p1[0])*(p3[1]-p1[1]) - (p2[1]-p1[1])*(p3[0]-p1[0])
+	a := math.Hypot(p2[0]-p1[0], p2[1]-p1[1])
+	b := math.Hypot(p3[0]-p2[0], p3[1]-p2[1])
+	c := math.Hypot(p3[0]-p1[0], p3[1]-p1[1])
+	if a == 0 || b == 0 || c == 0 {
+		return 0
+	}
+	return 2 * cross / (a * b * c)
+}
@@ -0,0 +1,130 @@
+package leastsquares
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+func TestRidgeSolver(t *testing.T) {
+	for _, m := range []*linalg.Matrix{randomMatrix(7, 3), randomMatrix(3, 5)} {
+		b := linalg.RandVector(m.Rows)
+		lambda := 0.3
+		actual := NewRidgeSolver(m, lambda).Solve(b)
+
+		normal := m.Transpose().Mul(m)
+		for i := 0; i < normal.Rows; i++ {
+			normal.Set(i, i, normal.Get(i, i)+lambda)
+		}
+		rhs := m.Transpose().Mul(linalg.NewMatrixColumn(b))
+		expected := ludecomp.Decompose(normal).Solve(rhs.Data)
+		if vectorDiff(actual, expected) > 1e-8 {
+			t.Error("expected", expected, "but got", actual)
+		}
+	}
+
+	m := randomMatrix(7, 3)
+	b := linalg.RandVector(7)
+	actual := NewRidgeSolver(m, 0).Solve(b)
+	expected := NewSolver(m).Solve(b)
+	if vectorDiff(actual, expected) > 1e-8 {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
+
+func TestRidgeSolverNegativeLambda(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for negative lambda")
+		}
+	}()
+	NewRidgeSolver(randomMatrix(4, 2), -1)
+}
+
+func TestRidgeHatTrace(t *testing.T) {
+	m := randomMatrix(6, 4)
+	lambda := 0.5
+
+	normal := m.Transpose().Mul(m)
+	for i := 0; i < normal.Rows; i++ {
+		normal.Set(i, i, normal.Get(i, i)+lambda)
+	}
+	lu := ludecomp.Decompose(normal)
+	var expected float64
+	for i := 0; i < m.Rows; i++ {
+		row := linalg.Vector(m.Data[i*m.Cols : (i+1)*m.Cols])
+		expected += row.Dot(lu.Solve(row))
+	}
+
+	actual := NewRidgeSolver(m, lambda).hatTrace()
+	if math.Abs(actual-expected) > 1e-8 {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
+
+func TestRidgeSelection(t *testing.T) {
+	m, b, solution := illPosedProblem()
+	lambdas := make([]float64, 25)
+	for i := range lambdas {
+		lambdas[i] = math.Pow(10, float64(i)/2-12)
+	}
+	unregularizedErr := solutionError(m, b, 1e-14, solution)
+
+	gcvLambda, scores := RidgeGCV(m, b, lambdas)
+	if len(scores) != len(lambdas) {
+		t.Fatal("unexpected number of scores:", len(scores))
+	}
+	for _, s := range scores {
+		if !(s >= scores[indexOfLambda(lambdas, gcvLambda)]) {
+			t.Fatal("best lambda does not have the best score")
+		}
+	}
+	if err := solutionError(m, b, gcvLambda, solution); err > unregularizedErr/10 {
+		t.Error("GCV lambda", gcvLambda, "gave error", err, "vs", unregularizedErr)
+	}
+
+	curveLambda := RidgeLCurve(m, b, lambdas)
+	if err := solutionError(m, b, curveLambda, solution); err > unregularizedErr/10 {
+		t.Error("L-curve lambda", curveLambda, "gave error", err, "vs", unregularizedErr)
+	}
+}
+
+// illPosedProblem creates a noisy problem with a
+// Hilbert matrix, for which the plain least-squares
+// solution is dominated by noise.
+func illPosedProblem() (m *linalg.Matrix, b, solution linalg.Vector) {
+	gen := rand.New(rand.NewSource(1337))
+	m = linalg.NewMatrix(20, 10)
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j < m.Cols; j++ {
+			m.Set(i, j, 1/float64(i+j+1))
+		}
+	}
+	solution = make(linalg.Vector, m.Cols)
+	for i := range solution {
+		solution[i] = 1
+	}
+	b = linalg.Vector(m.Mul(linalg.NewMatrixColumn(solution)).Data)
+	for i := range b {
+		b[i] += gen.NormFloat64() * 1e-4
+	}
+	return
+}
+
+func solutionError(m *linalg.Matrix, b linalg.Vector, lambda float64,
+	solution linalg.Vector) float64 {
+	x := NewRidgeSolver(m, lambda).Solve(b)
+	return x.Scale(-1).Add(solution).Mag()
+}
+
+func indexOfLambda(lambdas []float64, lambda float64) int {
+	for i, x := range lambdas {
+		if x == lambda {
+			return i
+		}
+	}
+	panic("lambda not found")
+}
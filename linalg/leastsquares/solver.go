@@ -51,3 +51,25 @@ func (s *Solver) backSubstitute(b linalg.Vector) linalg.Vector {
 	}
 	return res
 }
+
+// NormalInverse computes (A'*A)^-1, where A is
+// represented by s.
+//
+// This is computed from the triangular factor of A
+// rather than by inverting A'*A, since forming A'*A
+// squares the condition number.
+// For least-squares fits, it is the covariance of the
+// solution up to a factor of the noise variance.
+func (s *Solver) NormalInverse() *linalg.Matrix {
+	// If A = Q*R, then (A'*A)^-1 = R^-1 * R^-T.
+	n := s.upperTriangular.Rows
+	rInv := linalg.NewMatrix(n, n)
+	for col := 0; col < n; col++ {
+		basis := make(linalg.Vector, n)
+		basis[col] = 1
+		for row, x := range s.backSubstitute(basis) {
+			rInv.Set(row, col, x)
+		}
+	}
+	return rInv.Mul(rInv.Transpose())
+}
@@ -46,3 +46,20 @@ func vectorDiff(v1, v2 linalg.Vector) float64 {
 	}
 	return sum
 }
+
+func TestSolverNormalInverse(t *testing.T) {
+	matrix := randomMatrix(6, 4)
+	actual := NewSolver(matrix).NormalInverse()
+	product := actual.Mul(matrix.Transpose().Mul(matrix))
+	for i := 0; i < product.Rows; i++ {
+		for j := 0; j < product.Cols; j++ {
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			if math.Abs(product.Get(i, j)-expected) > 1e-8 {
+				t.Fatal("not an inverse:", actual)
+			}
+		}
+	}
+}
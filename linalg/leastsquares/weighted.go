@@ -0,0 +1,113 @@
+package leastsquares
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/kahan"
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/cholesky"
+)
+
+// A WeightedSolver solves weighted least-squares
+// problems, minimizing (A*x-b)'*W*(A*x-b) for x given
+// a vector b.
+//
+// The weight matrix W is either diagonal, giving each
+// equation its own weight, or the inverse of the
+// covariance matrix of the errors in b.
+type WeightedSolver struct {
+	solver *Solver
+
+	sqrtWeights linalg.Vector
+	covariance  *cholesky.Cholesky
+}
+
+// NewWeightedSolver creates a WeightedSolver which
+// weights the i-th row of m by weights[i].
+//
+// The weights must be non-negative, and the rows with
+// positive weights must have independent columns.
+func NewWeightedSolver(m *linalg.Matrix, weights linalg.Vector) *WeightedSolver {
+	if len(weights) != m.Rows {
+		panic("dimension mismatch")
+	}
+	sqrtWeights := make(linalg.Vector, len(weights))
+	for i, w := range weights {
+		if w < 0 {
+			panic("weights must be non-negative")
+		}
+		sqrtWeights[i] = math.Sqrt(w)
+	}
+	scaled := m.Copy()
+	for i, w := range sqrtWeights {
+		for j := 0; j < m.Cols; j++ {
+			scaled.Set(i, j, scaled.Get(i, j)*w)
+		}
+	}
+	return &WeightedSolver{
+		solver:      NewSolver(scaled),
+		sqrtWeights: sqrtWeights,
+	}
+}
+
+// NewCovarianceSolver creates a WeightedSolver for
+// generalized least squares, where the errors in b
+// have the symmetric positive-definite covariance
+// matrix cov.
+//
+// The problem is whitened by the Cholesky factor of
+// cov and then solved with QR decomposition.
+func NewCovarianceSolver(m, cov *linalg.Matrix) (*WeightedSolver, error) {
+	if cov.Rows != m.Rows || !cov.Square() {
+		panic("dimension mismatch")
+	}
+	chol, err := cholesky.DecomposeChecked(cov)
+	if err != nil {
+		return nil, err
+	}
+	whitened := linalg.NewMatrix(m.Rows, m.Cols)
+	for j := 0; j < m.Cols; j++ {
+		col := forwardSubstitute(chol, m.Col(j))
+		for i, x := range col {
+			whitened.Set(i, j, x)
+		}
+	}
+	return &WeightedSolver{
+		solver:     NewSolver(whitened),
+		covariance: chol,
+	}, nil
+}
+
+// Solve finds the x which minimizes the weighted
+// squared error for the given b.
+func (w *WeightedSolver) Solve(b linalg.Vector) linalg.Vector {
+	if w.covariance != nil {
+		return w.solver.Solve(forwardSubstitute(w.covariance, b))
+	}
+	if len(b) != len(w.sqrtWeights) {
+		panic("dimension mismatch")
+	}
+	scaled := make(linalg.Vector, len(b))
+	for i, x := range b {
+		scaled[i] = x * w.sqrtWeights[i]
+	}
+	return w.solver.Solve(scaled)
+}
+
+// forwardSubstitute solves L*x = b, where L is the
+// lower-triangular Cholesky factor.
+func forwardSubstitute(c *cholesky.Cholesky, b linalg.Vector) linalg.Vector {
+	if len(b) != c.Size() {
+		panic("dimension mismatch")
+	}
+	res := make(linalg.Vector, len(b))
+	for i := range res {
+		sum := kahan.NewSummer64()
+		sum.Add(b[i])
+		for j := 0; j < i; j++ {
+			sum.Add(-c.Get(i, j) * res[j])
+		}
+		res[i] = sum.Sum() / c.Get(i, i)
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package leastsquares
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+func TestWeightedSolverDuplicates(t *testing.T) {
+	m := randomMatrix(6, 3)
+	b := linalg.RandVector(6)
+
+	// Weighting a row by 2 is like repeating it, and a
+	// weight of 0 is like deleting it.
+	weights := linalg.Vector{2, 1, 0, 1, 1, 1}
+	rows := []int{0, 0, 1, 3, 4, 5}
+	equivalent := linalg.NewMatrix(len(rows), m.Cols)
+	equivalentB := make(linalg.Vector, len(rows))
+	for i, row := range rows {
+		copy(equivalent.Data[i*m.Cols:], m.Data[row*m.Cols:(row+1)*m.Cols])
+		equivalentB[i] = b[row]
+	}
+
+	actual := NewWeightedSolver(m, weights).Solve(b)
+	expected := NewSolver(equivalent).Solve(equivalentB)
+	if vectorDiff(actual, expected) > 1e-8 {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
+
+func TestCovarianceSolver(t *testing.T) {
+	m := randomMatrix(6, 3)
+	b := linalg.RandVector(6)
+	factor := randomMatrix(6, 6)
+	cov := factor.Mul(factor.Transpose())
+	for i := 0; i < 6; i++ {
+		cov.Set(i, i, cov.Get(i, i)+1)
+	}
+
+	solver, err := NewCovarianceSolver(m, cov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual := solver.Solve(b)
+
+	// Solve (A'*C^-1*A)*x = A'*C^-1*b directly.
+	covLU := ludecomp.Decompose(cov)
+	invA := linalg.NewMatrix(m.Rows, m.Cols)
+	for j := 0; j < m.Cols; j++ {
+		for i, x := range covLU.Solve(m.Col(j)) {
+			invA.Set(i, j, x)
+		}
+	}
+	lhs := m.Transpose().Mul(invA)
+	rhs := invA.Transpose().Mul(linalg.NewMatrixColumn(b))
+	expected := ludecomp.Decompose(lhs).Solve(rhs.Data)
+	if vectorDiff(actual, expected) > 1e-8 {
+		t.Error("expected", expected, "but got", actual)
+	}
+
+	// A diagonal covariance is like inverse weights.
+	diagCov := linalg.NewMatrix(6, 6)
+	weights := make(linalg.Vector, 6)
+	for i := range weights {
+		variance := rand.Float64() + 0.5
+		diagCov.Set(i, i, variance)
+		weights[i] = 1 / variance
+	}
+	solver, err = NewCovarianceSolver(m, diagCov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual = solver.Solve(b)
+	expected = NewWeightedSolver(m, weights).Solve(b)
+	if vectorDiff(actual, expected) > 1e-8 {
+		t.Error("expected", expected, "but got", actual)
+	}
+
+	diagCov.Set(2, 2, -1)
+	if _, err := NewCovarianceSolver(m, diagCov); err == nil {
+		t.Error("expected error for indefinite covariance")
+	}
+}
+
+func randomMatrix(rows, cols int) *linalg.Matrix {
+	res := linalg.NewMatrix(rows, cols)
+	for i := range res.Data {
+		res.Data[i] = rand.Float64()*2 - 1
+	}
+	return res
+}
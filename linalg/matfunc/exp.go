@@ -0,0 +1,85 @@
+// Package matfunc computes functions of square
+// matrices, such as the matrix exponential.
+package matfunc
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+// padeDegree is the degree of the numerator and
+// denominator of the Pade approximant used by Exp.
+const padeDegree = 6
+
+var ErrSingular = errors.New("matrix is singular")
+
+// Exp computes the matrix exponential e^m of a
+// square matrix m.
+//
+// This uses scaling and squaring: m is scaled by a
+// power of two until its infinity-norm is at most
+// 1/2, a diagonal Pade approximant is evaluated, and
+// the result is squared back up.
+func Exp(m *linalg.Matrix) *linalg.Matrix {
+	if !m.Square() {
+		panic("dimension mismatch")
+	}
+
+	norm := normInf(m)
+	var squarings int
+	if norm > 0.5 {
+		squarings = int(math.Floor(math.Log2(norm))) + 2
+	}
+	scaled := m.Copy().Scale(math.Pow(2, -float64(squarings)))
+
+	c := 0.5
+	power := scaled.Copy()
+	numerator := linalg.NewMatrixIdentity(m.Rows).Add(scaled.Copy().Scale(c))
+	denominator := linalg.NewMatrixIdentity(m.Rows).Add(scaled.Copy().Scale(-c))
+	for k := 2; k <= padeDegree; k++ {
+		c *= float64(padeDegree-k+1) / float64(k*(2*padeDegree-k+1))
+		power = scaled.Mul(power)
+		numerator.Add(power.Copy().Scale(c))
+		if k%2 == 0 {
+			denominator.Add(power.Copy().Scale(c))
+		} else {
+			denominator.Add(power.Copy().Scale(-c))
+		}
+	}
+
+	res := solveMatrix(ludecomp.Decompose(denominator), numerator)
+	for i := 0; i < squarings; i++ {
+		res = res.Mul(res)
+	}
+	return res
+}
+
+// solveMatrix solves A*X = B for X, where A is
+// represented by its LU decomposition.
+func solveMatrix(lu *ludecomp.LU, b *linalg.Matrix) *linalg.Matrix {
+	res := linalg.NewMatrix(b.Rows, b.Cols)
+	for col := 0; col < b.Cols; col++ {
+		solution := lu.Solve(b.Col(col))
+		for row, x := range solution {
+			res.Set(row, col, x)
+		}
+	}
+	return res
+}
+
+// normInf computes the infinity-norm (the maximum
+// absolute row sum) of a matrix.
+func normInf(m *linalg.Matrix) float64 {
+	var res float64
+	for i := 0; i < m.Rows; i++ {
+		var sum float64
+		for j := 0; j < m.Cols; j++ {
+			sum += math.Abs(m.Get(i, j))
+		}
+		res = math.Max(res, sum)
+	}
+	return res
+}
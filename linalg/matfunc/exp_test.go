@@ -0,0 +1,68 @@
+package matfunc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestExpRotation(t *testing.T) {
+	for _, theta := range []float64{0.1, 1, 10, 50} {
+		m := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{0, -theta, theta, 0}}
+		expected := &linalg.Matrix{
+			Rows: 2,
+			Cols: 2,
+			Data: []float64{
+				math.Cos(theta), -math.Sin(theta),
+				math.Sin(theta), math.Cos(theta),
+			},
+		}
+		if diff := maxDifference(Exp(m), expected); diff > 1e-12 {
+			t.Error("bad rotation for theta", theta, "error", diff)
+		}
+	}
+}
+
+func TestExpNilpotent(t *testing.T) {
+	m := &linalg.Matrix{
+		Rows: 3,
+		Cols: 3,
+		Data: []float64{
+			0, 2, 3,
+			0, 0, 4,
+			0, 0, 0,
+		},
+	}
+
+	// e^m = I + m + m^2/2 since m^3 = 0.
+	expected := linalg.NewMatrixIdentity(3).Add(m).Add(m.Mul(m).Scale(0.5))
+	if diff := maxDifference(Exp(m), expected); diff > 1e-12 {
+		t.Error("expected", expected, "but got", Exp(m))
+	}
+}
+
+func TestExpDiagonalizable(t *testing.T) {
+	// m = P*diag(1, -2)*P^-1 where P = [1 1; 1 2].
+	m := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{4, -3, 6, -5}}
+	e1, e2 := math.E, math.Exp(-2)
+	expected := &linalg.Matrix{
+		Rows: 2,
+		Cols: 2,
+		Data: []float64{
+			2*e1 - e2, e2 - e1,
+			2*e1 - 2*e2, 2*e2 - e1,
+		},
+	}
+	if diff := maxDifference(Exp(m), expected); diff > 1e-12 {
+		t.Error("expected", expected, "but got", Exp(m))
+	}
+}
+
+func maxDifference(m1, m2 *linalg.Matrix) float64 {
+	var res float64
+	for i, x := range m1.Data {
+		res = math.Max(res, math.Abs(x-m2.Data[i]))
+	}
+	return res
+}
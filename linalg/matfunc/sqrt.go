@@ -0,0 +1,59 @@
+package matfunc
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+const (
+	sqrtMaxIterations = 100
+	sqrtPrecision     = 1e-14
+)
+
+var ErrNoConvergence = errors.New("iteration did not converge")
+
+// Sqrt computes the principal square root of a
+// square matrix m using the Denman-Beavers iteration.
+//
+// The matrix m must not have any eigenvalues on the
+// closed negative real axis.
+// If an intermediate matrix is singular, ErrSingular
+// is returned; if the iteration fails to converge,
+// the last iterate is returned with ErrNoConvergence.
+func Sqrt(m *linalg.Matrix) (*linalg.Matrix, error) {
+	if !m.Square() {
+		panic("dimension mismatch")
+	}
+	y := m.Copy()
+	z := linalg.NewMatrixIdentity(m.Rows)
+	for i := 0; i < sqrtMaxIterations; i++ {
+		yInv, err := inverse(y)
+		if err != nil {
+			return nil, err
+		}
+		zInv, err := inverse(z)
+		if err != nil {
+			return nil, err
+		}
+		newY := y.Copy().Add(zInv).Scale(0.5)
+		newZ := z.Add(yInv).Scale(0.5)
+
+		diff := newY.Copy().Add(y.Scale(-1))
+		y, z = newY, newZ
+		if normInf(diff) <= sqrtPrecision*normInf(y) {
+			return y, nil
+		}
+	}
+	return y, ErrNoConvergence
+}
+
+func inverse(m *linalg.Matrix) (*linalg.Matrix, error) {
+	lu := ludecomp.Decompose(m)
+	if lu.PivotScale() < math.Nextafter(1, 2)-1 {
+		return nil, ErrSingular
+	}
+	return solveMatrix(lu, linalg.NewMatrixIdentity(m.Rows)), nil
+}
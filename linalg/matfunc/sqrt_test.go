@@ -0,0 +1,46 @@
+package matfunc
+
+import (
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestSqrtTriangular(t *testing.T) {
+	// The principal square root of [4 5; 0 9] is [2 1; 0 3].
+	m := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{4, 5, 0, 9}}
+	expected := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{2, 1, 0, 3}}
+	actual, err := Sqrt(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := maxDifference(actual, expected); diff > 1e-12 {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
+
+func TestSqrtGeneral(t *testing.T) {
+	m := &linalg.Matrix{
+		Rows: 3,
+		Cols: 3,
+		Data: []float64{
+			5, 1, -2,
+			0.5, 4, 1,
+			1, -1, 6,
+		},
+	}
+	actual, err := Sqrt(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := maxDifference(actual.Mul(actual), m); diff > 1e-12 {
+		t.Error("square of root does not match:", actual.Mul(actual))
+	}
+}
+
+func TestSqrtSingular(t *testing.T) {
+	m := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{1, 2, 2, 4}}
+	if _, err := Sqrt(m); err != ErrSingular {
+		t.Error("expected ErrSingular but got", err)
+	}
+}
@@ -0,0 +1,93 @@
+package matfunc
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/eigen"
+)
+
+var ErrDomain = errors.New("eigenvalue outside of function domain")
+
+// SymmetricFunc applies a scalar function f to a
+// symmetric matrix m by applying it to each of the
+// eigenvalues of m.
+// In other words, if m = V*D*V', then this returns
+// V*f(D)*V'.
+func SymmetricFunc(m *linalg.Matrix, f func(float64) float64) *linalg.Matrix {
+	if !m.Square() {
+		panic("dimension mismatch")
+	}
+	vals, vecs := eigen.Symmetric(m)
+	mapped := make([]float64, len(vals))
+	for i, x := range vals {
+		mapped[i] = f(x)
+	}
+	return eigenProduct(m.Rows, mapped, vecs)
+}
+
+// SymmetricSqrt computes the square root of a
+// symmetric positive semi-definite matrix.
+//
+// Eigenvalues which are negative by no more than
+// rounding error are treated as zero; if any other
+// eigenvalue is negative, ErrDomain is returned.
+func SymmetricSqrt(m *linalg.Matrix) (*linalg.Matrix, error) {
+	if !m.Square() {
+		panic("dimension mismatch")
+	}
+	vals, vecs := eigen.Symmetric(m)
+	tolerance := eigenTolerance(vals)
+	for i, x := range vals {
+		if x < -tolerance {
+			return nil, ErrDomain
+		}
+		vals[i] = math.Sqrt(math.Max(0, x))
+	}
+	return eigenProduct(m.Rows, vals, vecs), nil
+}
+
+// SymmetricLog computes the principal logarithm of a
+// symmetric positive-definite matrix.
+//
+// If m has an eigenvalue which is not positive, then
+// ErrDomain is returned.
+func SymmetricLog(m *linalg.Matrix) (*linalg.Matrix, error) {
+	if !m.Square() {
+		panic("dimension mismatch")
+	}
+	vals, vecs := eigen.Symmetric(m)
+	for i, x := range vals {
+		if x <= 0 {
+			return nil, ErrDomain
+		}
+		vals[i] = math.Log(x)
+	}
+	return eigenProduct(m.Rows, vals, vecs), nil
+}
+
+// eigenProduct computes V*D*V', where the columns
+// of V are vecs and D is diag(vals).
+func eigenProduct(size int, vals []float64, vecs []linalg.Vector) *linalg.Matrix {
+	res := linalg.NewMatrix(size, size)
+	for k, vec := range vecs {
+		for i := 0; i < size; i++ {
+			scaled := vals[k] * vec[i]
+			for j := 0; j < size; j++ {
+				res.Data[i*size+j] += scaled * vec[j]
+			}
+		}
+	}
+	return res
+}
+
+// eigenTolerance returns the magnitude below which
+// an eigenvalue is indistinguishable from zero.
+func eigenTolerance(vals []float64) float64 {
+	var max float64
+	for _, x := range vals {
+		max = math.Max(max, math.Abs(x))
+	}
+	return float64(len(vals)) * max * 1e-12
+}
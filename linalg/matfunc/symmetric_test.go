@@ -0,0 +1,64 @@
+package matfunc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+var symmetricTestMatrix = &linalg.Matrix{
+	Rows: 3,
+	Cols: 3,
+	Data: []float64{
+		4, 1, 0.5,
+		1, 3, -1,
+		0.5, -1, 5,
+	},
+}
+
+func TestSymmetricSqrt(t *testing.T) {
+	root, err := SymmetricSqrt(symmetricTestMatrix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := maxDifference(root.Mul(root), symmetricTestMatrix); diff > 1e-8 {
+		t.Error("square of root does not match:", root.Mul(root))
+	}
+	if diff := maxDifference(root, root.Transpose()); diff > 1e-8 {
+		t.Error("root is not symmetric:", root)
+	}
+
+	negative := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{1, 2, 2, 1}}
+	if _, err := SymmetricSqrt(negative); err != ErrDomain {
+		t.Error("expected ErrDomain but got", err)
+	}
+}
+
+func TestSymmetricLog(t *testing.T) {
+	log, err := SymmetricLog(symmetricTestMatrix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := maxDifference(Exp(log), symmetricTestMatrix); diff > 1e-8 {
+		t.Error("exponential of log does not match:", Exp(log))
+	}
+
+	// log([e 0; 0 e^2]) = [1 0; 0 2].
+	diag := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{math.E, 0, 0, math.E * math.E}}
+	expected := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{1, 0, 0, 2}}
+	log, err = SymmetricLog(diag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := maxDifference(log, expected); diff > 1e-8 {
+		t.Error("expected", expected, "but got", log)
+	}
+}
+
+func TestSymmetricFunc(t *testing.T) {
+	actual := SymmetricFunc(symmetricTestMatrix, math.Exp)
+	if diff := maxDifference(actual, Exp(symmetricTestMatrix)); diff > 1e-6 {
+		t.Error("expected", Exp(symmetricTestMatrix), "but got", actual)
+	}
+}
@@ -0,0 +1,272 @@
+package linalg
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const matrixMarketBanner = "%%MatrixMarket"
+
+var (
+	ErrMatrixMarketHeader    = errors.New("invalid Matrix Market header")
+	ErrMatrixMarketSupported = errors.New("unsupported Matrix Market variant")
+)
+
+// ReadMatrixMarket reads a matrix in the Matrix Market
+// exchange format.
+//
+// Both the dense "array" format and the sparse
+// "coordinate" format are supported, with real,
+// integer, or pattern entries and general, symmetric,
+// or skew-symmetric storage.
+// Complex and hermitian matrices are not supported.
+func ReadMatrixMarket(r io.Reader) (*Matrix, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrMatrixMarketHeader
+	}
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != strings.ToLower(matrixMarketBanner) ||
+		header[1] != "matrix" {
+		return nil, ErrMatrixMarketHeader
+	}
+	format, field, symmetry := header[2], header[3], header[4]
+
+	switch field {
+	case "real", "double", "integer":
+	case "pattern":
+		if format != "coordinate" {
+			return nil, ErrMatrixMarketHeader
+		}
+	default:
+		return nil, ErrMatrixMarketSupported
+	}
+	if symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric" {
+		return nil, ErrMatrixMarketSupported
+	}
+
+	nextLine := func() ([]string, error) {
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "%") {
+				continue
+			}
+			return strings.Fields(line), nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	fields, err := nextLine()
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "array":
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid array size line: %v", fields)
+		}
+		sizes, err := parseInts(fields)
+		if err != nil {
+			return nil, err
+		}
+		if !matrixMarketSizeOK(sizes[0], sizes[1]) {
+			return nil, fmt.Errorf("matrix too large: %v", fields)
+		}
+		res, err := readMatrixMarketArray(nextLine, sizes[0], sizes[1], symmetry)
+		if err != nil {
+			return nil, err
+		}
+		return res, checkMatrixMarketEnd(nextLine)
+	case "coordinate":
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid coordinate size line: %v", fields)
+		}
+		sizes, err := parseInts(fields)
+		if err != nil {
+			return nil, err
+		}
+		if !matrixMarketSizeOK(sizes[0], sizes[1]) {
+			return nil, fmt.Errorf("matrix too large: %v", fields)
+		}
+		res, err := readMatrixMarketCoordinate(nextLine, sizes[0], sizes[1], sizes[2],
+			field == "pattern", symmetry)
+		if err != nil {
+			return nil, err
+		}
+		return res, checkMatrixMarketEnd(nextLine)
+	default:
+		return nil, ErrMatrixMarketSupported
+	}
+}
+
+// WriteMatrixMarket writes m in the dense "array"
+// Matrix Market format.
+func WriteMatrixMarket(w io.Writer, m *Matrix) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix array real general\n", matrixMarketBanner)
+	fmt.Fprintf(bw, "%d %d\n", m.Rows, m.Cols)
+	for col := 0; col < m.Cols; col++ {
+		for row := 0; row < m.Rows; row++ {
+			bw.WriteString(formatExact(m.Get(row, col)))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteMatrixMarketSparse writes the non-zero entries
+// of m in the sparse "coordinate" Matrix Market format.
+func WriteMatrixMarketSparse(w io.Writer, m *Matrix) error {
+	var nonZero int
+	for _, x := range m.Data {
+		if x != 0 {
+			nonZero++
+		}
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s matrix coordinate real general\n", matrixMarketBanner)
+	fmt.Fprintf(bw, "%d %d %d\n", m.Rows, m.Cols, nonZero)
+	for col := 0; col < m.Cols; col++ {
+		for row := 0; row < m.Rows; row++ {
+			if val := m.Get(row, col); val != 0 {
+				fmt.Fprintf(bw, "%d %d %s\n", row+1, col+1, formatExact(val))
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+func readMatrixMarketArray(nextLine func() ([]string, error), rows, cols int,
+	symmetry string) (*Matrix, error) {
+	if symmetry != "general" && rows != cols {
+		return nil, fmt.Errorf("%s matrix must be square", symmetry)
+	}
+	res := NewMatrix(rows, cols)
+	for col := 0; col < cols; col++ {
+		startRow := 0
+		if symmetry == "symmetric" {
+			startRow = col
+		} else if symmetry == "skew-symmetric" {
+			startRow = col + 1
+		}
+		for row := startRow; row < rows; row++ {
+			fields, err := nextLine()
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) != 1 {
+				return nil, fmt.Errorf("invalid array entry: %v", fields)
+			}
+			val, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				return nil, err
+			}
+			setMatrixMarketEntry(res, row, col, val, symmetry)
+		}
+	}
+	return res, nil
+}
+
+func readMatrixMarketCoordinate(nextLine func() ([]string, error), rows, cols, count int,
+	pattern bool, symmetry string) (*Matrix, error) {
+	if symmetry != "general" && rows != cols {
+		return nil, fmt.Errorf("%s matrix must be square", symmetry)
+	}
+	res := NewMatrix(rows, cols)
+	for i := 0; i < count; i++ {
+		fields, err := nextLine()
+		if err != nil {
+			return nil, err
+		}
+		if (pattern && len(fields) != 2) || (!pattern && len(fields) != 3) {
+			return nil, fmt.Errorf("invalid coordinate entry: %v", fields)
+		}
+		indices, err := parseInts(fields[:2])
+		if err != nil {
+			return nil, err
+		}
+		row, col := indices[0]-1, indices[1]-1
+		if row < 0 || col < 0 || row >= rows || col >= cols {
+			return nil, fmt.Errorf("entry out of bounds: %v", fields)
+		}
+		val := 1.0
+		if !pattern {
+			val, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		setMatrixMarketEntry(res, row, col, val, symmetry)
+	}
+	return res, nil
+}
+
+// matrixMarketSizeOK checks that a matrix with the given
+// dimensions from an untrusted header can reasonably be
+// allocated.
+// Like Matrix.UnmarshalBinary, both dimensions must fit
+// in an int32, and so must the total number of entries.
+func matrixMarketSizeOK(rows, cols int) bool {
+	if rows > math.MaxInt32 || cols > math.MaxInt32 {
+		return false
+	}
+	return uint64(rows)*uint64(cols) <= math.MaxInt32
+}
+
+// checkMatrixMarketEnd makes sure that there are no
+// entries past the ones declared in the size line.
+func checkMatrixMarketEnd(nextLine func() ([]string, error)) error {
+	fields, err := nextLine()
+	if err == io.ErrUnexpectedEOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected entry past end of matrix: %v", fields)
+}
+
+func setMatrixMarketEntry(m *Matrix, row, col int, val float64, symmetry string) {
+	m.Set(row, col, val)
+	if row == col {
+		return
+	}
+	switch symmetry {
+	case "symmetric":
+		m.Set(col, row, val)
+	case "skew-symmetric":
+		m.Set(col, row, -val)
+	}
+}
+
+func parseInts(fields []string) ([]int, error) {
+	res := make([]int, len(fields))
+	for i, f := range fields {
+		x, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, err
+		}
+		if x < 0 {
+			return nil, fmt.Errorf("unexpected negative value: %d", x)
+		}
+		res[i] = x
+	}
+	return res, nil
+}
+
+// formatExact formats a number so that it can be
+// parsed back without any loss of precision.
+func formatExact(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
@@ -0,0 +1,100 @@
+package linalg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMatrixMarketRoundTrip(t *testing.T) {
+	mat := &Matrix{
+		Rows: 3,
+		Cols: 2,
+		Data: []float64{
+			1, 0,
+			0, -3.25,
+			1.0 / 7.0, 0,
+		},
+	}
+	writers := map[string]func(w *bytes.Buffer, m *Matrix) error{
+		"array": func(w *bytes.Buffer, m *Matrix) error {
+			return WriteMatrixMarket(w, m)
+		},
+		"coordinate": func(w *bytes.Buffer, m *Matrix) error {
+			return WriteMatrixMarketSparse(w, m)
+		},
+	}
+	for name, writer := range writers {
+		var buf bytes.Buffer
+		if err := writer(&buf, mat); err != nil {
+			t.Fatal(name, err)
+		}
+		decoded, err := ReadMatrixMarket(&buf)
+		if err != nil {
+			t.Error(name, err)
+		} else if !matricesIdentical(decoded, mat) {
+			t.Error(name, "expected", mat, "but got", decoded)
+		}
+	}
+}
+
+func TestMatrixMarketSymmetric(t *testing.T) {
+	input := `%%MatrixMarket matrix coordinate real symmetric
+% a comment line
+3 3 4
+1 1 2
+2 1 -1
+3 2 5.5
+3 3 1
+`
+	expected := &Matrix{
+		Rows: 3,
+		Cols: 3,
+		Data: []float64{
+			2, -1, 0,
+			-1, 0, 5.5,
+			0, 5.5, 1,
+		},
+	}
+	actual, err := ReadMatrixMarket(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !matricesIdentical(actual, expected) {
+		t.Error("expected", expected, "but got", actual)
+	}
+
+	input = `%%MatrixMarket matrix array integer skew-symmetric
+2 2
+3
+`
+	expected = &Matrix{Rows: 2, Cols: 2, Data: []float64{0, -3, 3, 0}}
+	actual, err = ReadMatrixMarket(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !matricesIdentical(actual, expected) {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
+
+func TestMatrixMarketErrors(t *testing.T) {
+	inputs := []string{
+		"",
+		"%%MatrixMarket matrix array complex general\n1 1\n1 2\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+		"not a header\n1 1\n1\n",
+		"%%MatrixMarket matrix array real general\n1 1\n1\n2\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 1 1\n2 2 1\n",
+		"%%MatrixMarket matrix array real general\n-1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n4294967297 4294967297 0\n",
+		"%%MatrixMarket matrix coordinate real general\n65536 65536 0\n",
+		"%%MatrixMarket matrix array real general\n2147483648 1\n",
+	}
+	for _, input := range inputs {
+		if _, err := ReadMatrixMarket(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
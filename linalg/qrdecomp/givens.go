@@ -0,0 +1,62 @@
+package qrdecomp
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A Givens represents a rotation in the plane spanned
+// by two coordinate axes I and J.
+//
+// Applying the rotation to a vector v replaces v[I]
+// with Cos*v[I]+Sin*v[J] and v[J] with
+// Cos*v[J]-Sin*v[I].
+type Givens struct {
+	I, J int
+
+	Cos float64
+	Sin float64
+}
+
+// NewGivens creates a Givens rotation which maps any
+// vector with v[i] = a and v[j] = b to a vector with
+// v[i] = sqrt(a^2+b^2) and v[j] = 0.
+func NewGivens(i, j int, a, b float64) *Givens {
+	if b == 0 {
+		return &Givens{I: i, J: j, Cos: 1}
+	}
+	r := math.Hypot(a, b)
+	return &Givens{I: i, J: j, Cos: a / r, Sin: b / r}
+}
+
+// Apply returns the rotation of v.
+func (g *Givens) Apply(v linalg.Vector) linalg.Vector {
+	res := v.Copy()
+	res[g.I], res[g.J] = g.Cos*v[g.I]+g.Sin*v[g.J], g.Cos*v[g.J]-g.Sin*v[g.I]
+	return res
+}
+
+// ApplyRows left-multiplies m by the rotation in
+// place, rotating every column of m.
+func (g *Givens) ApplyRows(m *linalg.Matrix) {
+	for col := 0; col < m.Cols; col++ {
+		x, y := m.Get(g.I, col), m.Get(g.J, col)
+		m.Set(g.I, col, g.Cos*x+g.Sin*y)
+		m.Set(g.J, col, g.Cos*y-g.Sin*x)
+	}
+}
+
+// ApplyCols right-multiplies m by the transpose of the
+// rotation in place, rotating every row of m.
+//
+// If q*r is a factorization of a matrix and the
+// rotation is applied to the rows of r, then applying
+// it to the columns of q preserves the product.
+func (g *Givens) ApplyCols(m *linalg.Matrix) {
+	for row := 0; row < m.Rows; row++ {
+		x, y := m.Get(row, g.I), m.Get(row, g.J)
+		m.Set(row, g.I, g.Cos*x+g.Sin*y)
+		m.Set(row, g.J, g.Cos*y-g.Sin*x)
+	}
+}
@@ -0,0 +1,100 @@
+package qrdecomp
+
+import "github.com/unixpickle/num-analysis/linalg"
+
+// Hessenberg reduces a square matrix m to upper
+// Hessenberg form, meaning that every entry below the
+// subdiagonal is zero.
+//
+// It returns an orthogonal matrix q and a Hessenberg
+// matrix h such that m = q*h*q'.
+// If m is symmetric, then h is tridiagonal, up to
+// rounding error.
+func Hessenberg(m *linalg.Matrix) (q, h *linalg.Matrix) {
+	if !m.Square() {
+		panic("dimension mismatch")
+	}
+	n := m.Rows
+	h = m.Copy()
+	q = linalg.NewMatrixIdentity(n)
+
+	for col := 0; col+2 < n; col++ {
+		x := make(linalg.Vector, n-(col+1))
+		for i := range x {
+			x[i] = h.Get(col+1+i, col)
+		}
+		v, newPivot := householderVector(x)
+		if v == nil {
+			continue
+		}
+		reflectRows(h, v, col+1, 0, n)
+		reflectCols(h, v, col+1, 0, n)
+		reflectCols(q, v, col+1, 0, n)
+		h.Set(col+1, col, newPivot)
+		for i := col + 2; i < n; i++ {
+			h.Set(i, col, 0)
+		}
+	}
+
+	return
+}
+
+// householderVector finds a unit vector v such that
+// reflecting x across v gives a multiple of the first
+// basis vector.
+// It returns v and the first component of the result,
+// or nil if x is zero.
+func householderVector(x linalg.Vector) (linalg.Vector, float64) {
+	norm := x.Mag()
+	if norm == 0 {
+		return nil, 0
+	}
+	v := x.Copy()
+	newPivot := -norm
+	if x[0] < 0 {
+		newPivot = norm
+	}
+	v[0] -= newPivot
+	if mag := v.Mag(); mag == 0 {
+		return nil, 0
+	} else {
+		v.Scale(1 / mag)
+	}
+	return v, newPivot
+}
+
+// reflectRows applies the reflection (I - 2*v*v') to
+// rows start through start+len(v)-1 of m, only
+// modifying the columns in [colStart, colEnd).
+func reflectRows(m *linalg.Matrix, v linalg.Vector, start, colStart, colEnd int) {
+	for col := colStart; col < colEnd; col++ {
+		var dot float64
+		for i, x := range v {
+			dot += x * m.Get(start+i, col)
+		}
+		if dot == 0 {
+			continue
+		}
+		for i, x := range v {
+			m.Set(start+i, col, m.Get(start+i, col)-2*x*dot)
+		}
+	}
+}
+
+// reflectCols applies the reflection (I - 2*v*v') to
+// columns start through start+len(v)-1 of m, only
+// modifying the rows in [rowStart, rowEnd).
+func reflectCols(m *linalg.Matrix, v linalg.Vector, start, rowStart, rowEnd int) {
+	for row := rowStart; row < rowEnd; row++ {
+		var dot float64
+		for i, x := range v {
+			dot += x * m.Get(row, start+i)
+		}
+		if dot == 0 {
+			continue
+		}
+		for i, x := range v {
+			m.Set(row, start+i, m.Get(row, start+i)-2*x*dot)
+		}
+	}
+}
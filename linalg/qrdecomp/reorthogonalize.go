@@ -0,0 +1,150 @@
+package qrdecomp
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// An Orthogonalization is the result of running a
+// rank-revealing variant of Gram-Schmidt on an MxN
+// matrix A.
+type Orthogonalization struct {
+	// Q is an MxK matrix with orthonormal columns, where
+	// K is the number of independent columns of A.
+	Q *linalg.Matrix
+
+	// R is a KxN matrix such that Q*R = A.
+	// The column of R for the i-th independent column
+	// of A has zeroes below row i.
+	R *linalg.Matrix
+
+	// Dependent lists the indices of columns of A which
+	// were found to be in the span of earlier columns.
+	// These columns contribute nothing to Q.
+	Dependent []int
+}
+
+// ModifiedGramSchmidt orthogonalizes the columns of m
+// using modified Gram-Schmidt, which subtracts each new
+// basis vector from all of the remaining columns as
+// soon as it is found.
+//
+// A column is treated as dependent if, once it has
+// been orthogonalized, its magnitude is at most tol
+// times its original magnitude.
+//
+// The loss of orthogonality in Q grows with the
+// condition number of m.
+// For ill-conditioned matrices, use CGS2.
+func ModifiedGramSchmidt(m *linalg.Matrix, tol float64) *Orthogonalization {
+	cols := matrixColumns(m)
+	origMags := make([]float64, len(cols))
+	for i, col := range cols {
+		origMags[i] = col.Mag()
+	}
+	rCols := make([]linalg.Vector, len(cols))
+	var basis []linalg.Vector
+	var dependent []int
+
+	for i, col := range cols {
+		mag := col.Mag()
+		coeffs := rCols[i]
+		if mag <= tol*origMags[i] || mag == 0 {
+			dependent = append(dependent, i)
+		} else {
+			col.Scale(1 / mag)
+			coeffs = append(coeffs, mag)
+			basis = append(basis, col)
+			for j := i + 1; j < len(cols); j++ {
+				dot := col.Dot(cols[j])
+				cols[j].Add(col.Copy().Scale(-dot))
+				rCols[j] = append(rCols[j], dot)
+			}
+		}
+		rCols[i] = coeffs
+	}
+
+	return newOrthogonalization(m.Rows, basis, rCols, dependent)
+}
+
+// CGS2 orthogonalizes the columns of m using classical
+// Gram-Schmidt with one full reorthogonalization pass,
+// making Q orthogonal to working precision even when m
+// is ill-conditioned.
+//
+// The tol argument is used to detect dependent columns
+// just like in ModifiedGramSchmidt.
+func CGS2(m *linalg.Matrix, tol float64) *Orthogonalization {
+	var basis []linalg.Vector
+	var rCols []linalg.Vector
+	var dependent []int
+
+	for i, col := range matrixColumns(m) {
+		origMag := col.Mag()
+		coeffs := make(linalg.Vector, len(basis))
+		for pass := 0; pass < 2; pass++ {
+			projs := make(linalg.Vector, len(basis))
+			for j, b := range basis {
+				projs[j] = b.Dot(col)
+			}
+			for j, b := range basis {
+				col.Add(b.Copy().Scale(-projs[j]))
+			}
+			coeffs.Add(projs)
+		}
+		mag := col.Mag()
+		if mag <= tol*origMag || mag == 0 {
+			dependent = append(dependent, i)
+		} else {
+			basis = append(basis, col.Scale(1/mag))
+			coeffs = append(coeffs, mag)
+		}
+		rCols = append(rCols, coeffs)
+	}
+
+	return newOrthogonalization(m.Rows, basis, rCols, dependent)
+}
+
+// OrthogonalityLoss computes the Frobenius norm of
+// I - Q'*Q, which is zero when the columns of q are
+// exactly orthonormal.
+func OrthogonalityLoss(q *linalg.Matrix) float64 {
+	product := q.Transpose().Mul(q)
+	var sum float64
+	for i := 0; i < product.Rows; i++ {
+		for j := 0; j < product.Cols; j++ {
+			diff := product.Get(i, j)
+			if i == j {
+				diff -= 1
+			}
+			sum += diff * diff
+		}
+	}
+	return math.Sqrt(sum)
+}
+
+func matrixColumns(m *linalg.Matrix) []linalg.Vector {
+	res := make([]linalg.Vector, m.Cols)
+	for i := range res {
+		res[i] = m.Col(i)
+	}
+	return res
+}
+
+func newOrthogonalization(rows int, basis, rCols []linalg.Vector,
+	dependent []int) *Orthogonalization {
+	q := linalg.NewMatrix(rows, len(basis))
+	for j, col := range basis {
+		for i, x := range col {
+			q.Set(i, j, x)
+		}
+	}
+	r := linalg.NewMatrix(len(basis), len(rCols))
+	for j, col := range rCols {
+		for i, x := range col {
+			r.Set(i, j, x)
+		}
+	}
+	return &Orthogonalization{Q: q, R: r, Dependent: dependent}
+}
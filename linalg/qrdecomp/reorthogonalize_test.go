@@ -0,0 +1,117 @@
+package qrdecomp
+
+import (
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestModifiedGramSchmidt(t *testing.T) {
+	for _, m := range []*linalg.Matrix{test5x3Matrix, test4x4Matrix} {
+		testDecomposer(t, m, func(m *linalg.Matrix) (q, r *linalg.Matrix) {
+			res := ModifiedGramSchmidt(m, 1e-10)
+			return res.Q, res.R
+		}, m.Cols)
+	}
+}
+
+func TestCGS2(t *testing.T) {
+	for _, m := range []*linalg.Matrix{test5x3Matrix, test4x4Matrix} {
+		testDecomposer(t, m, func(m *linalg.Matrix) (q, r *linalg.Matrix) {
+			res := CGS2(m, 1e-10)
+			return res.Q, res.R
+		}, m.Cols)
+	}
+}
+
+func TestOrthogonalityLossHilbert(t *testing.T) {
+	m := hilbertMatrix(10)
+
+	mgs := ModifiedGramSchmidt(m, 0)
+	cgs2 := CGS2(m, 0)
+	if len(mgs.Dependent) != 0 || len(cgs2.Dependent) != 0 {
+		t.Fatal("unexpected dependent columns:", mgs.Dependent, cgs2.Dependent)
+	}
+
+	// The condition number of the 10x10 Hilbert matrix
+	// is roughly 1e13, so MGS loses most of its digits.
+	mgsLoss := OrthogonalityLoss(mgs.Q)
+	cgs2Loss := OrthogonalityLoss(cgs2.Q)
+	if mgsLoss < 1e-8 || mgsLoss > 1 {
+		t.Error("unexpected MGS loss of orthogonality:", mgsLoss)
+	}
+	if cgs2Loss > 1e-13 {
+		t.Error("unexpected CGS2 loss of orthogonality:", cgs2Loss)
+	}
+
+	for _, res := range []*Orthogonalization{mgs, cgs2} {
+		if diff := matrixDifference(res.Q.Mul(res.R), m); diff > 1e-12 {
+			t.Error("bad product with error", diff)
+		}
+	}
+}
+
+func TestDependentColumns(t *testing.T) {
+	// The 14x14 Hilbert matrix is numerically singular.
+	// MGS still loses orthogonality on the columns which
+	// are kept, while CGS2 does not.
+	hilbert := hilbertMatrix(14)
+	mgs := ModifiedGramSchmidt(hilbert, 1e-10)
+	cgs2 := CGS2(hilbert, 1e-10)
+	if len(mgs.Dependent) == 0 || len(cgs2.Dependent) == 0 {
+		t.Error("expected dependent columns")
+	}
+	verifyOrthogonalization(t, hilbert, mgs, 1e-8, 1e-2)
+	verifyOrthogonalization(t, hilbert, cgs2, 1e-8, 1e-13)
+
+	// Columns 2 and 4 are combinations of earlier ones.
+	m := &linalg.Matrix{
+		Rows: 4,
+		Cols: 5,
+		Data: []float64{
+			1, 2, 3, 0, 2,
+			0, 1, 1, 1, 2,
+			2, 0, 2, 1, 1,
+			1, 1, 2, 3, 4,
+		},
+	}
+	for _, res := range []*Orthogonalization{
+		ModifiedGramSchmidt(m, 1e-10),
+		CGS2(m, 1e-10),
+	} {
+		if len(res.Dependent) != 2 || res.Dependent[0] != 2 || res.Dependent[1] != 4 {
+			t.Error("unexpected dependent columns:", res.Dependent)
+		}
+		if res.Q.Cols != 3 || res.R.Rows != 3 {
+			t.Error("unexpected rank:", res.Q.Cols)
+		}
+		verifyOrthogonalization(t, m, res, 1e-10, 1e-13)
+	}
+}
+
+func verifyOrthogonalization(t *testing.T, m *linalg.Matrix, res *Orthogonalization,
+	tol, lossTol float64) {
+	if diff := matrixDifference(res.Q.Mul(res.R), m); diff > tol*float64(m.Rows*m.Cols) {
+		t.Error("bad product with error", diff)
+	}
+	if loss := OrthogonalityLoss(res.Q); loss > lossTol {
+		t.Error("Q is not orthogonal:", loss)
+	}
+	for j := 0; j < res.R.Cols; j++ {
+		for i := j + 1; i < res.R.Rows; i++ {
+			if res.R.Get(i, j) != 0 {
+				t.Fatal("R is not upper-triangular:", res.R)
+			}
+		}
+	}
+}
+
+func hilbertMatrix(size int) *linalg.Matrix {
+	res := linalg.NewMatrix(size, size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			res.Set(i, j, 1/float64(i+j+1))
+		}
+	}
+	return res
+}
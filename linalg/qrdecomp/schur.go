@@ -0,0 +1,210 @@
+package qrdecomp
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// schurIterationsPerEigenvalue bounds the number of
+// QR steps spent deflating each eigenvalue.
+const schurIterationsPerEigenvalue = 40
+
+var ErrNoConvergence = errors.New("QR algorithm did not converge")
+
+// Schur computes the real Schur decomposition of a
+// square matrix m.
+//
+// It returns an orthogonal matrix q and a
+// quasi-upper-triangular matrix t such that
+// m = q*t*q'.
+// The matrix t is upper triangular except for 2x2
+// blocks on its diagonal, each of which corresponds to
+// a pair of complex conjugate eigenvalues.
+// Blocks with real eigenvalues are always split, so
+// every real eigenvalue appears on the diagonal of t.
+//
+// This uses the Francis double-shift QR algorithm.
+// If it fails to converge, ErrNoConvergence is
+// returned.
+func Schur(m *linalg.Matrix) (q, t *linalg.Matrix, err error) {
+	q, t = Hessenberg(m)
+	n := t.Rows
+
+	var norm float64
+	for _, x := range t.Data {
+		norm = math.Max(norm, math.Abs(x))
+	}
+
+	hi := n - 1
+	var iters int
+	for hi >= 0 {
+		lo := hi
+		for lo > 0 {
+			if isNegligible(t.Get(lo, lo-1), t.Get(lo-1, lo-1), t.Get(lo, lo), norm) {
+				t.Set(lo, lo-1, 0)
+				break
+			}
+			lo--
+		}
+
+		if lo == hi {
+			hi--
+			iters = 0
+			continue
+		} else if lo == hi-1 {
+			splitSchurBlock(q, t, lo)
+			hi -= 2
+			iters = 0
+			continue
+		}
+
+		iters++
+		if iters > schurIterationsPerEigenvalue {
+			return nil, nil, ErrNoConvergence
+		}
+		francisStep(q, t, lo, hi, iters%10 == 0)
+	}
+
+	return q, t, nil
+}
+
+// SchurEigenvalues returns the eigenvalues of a
+// quasi-upper-triangular matrix, such as the t
+// returned by Schur, in the order in which they appear
+// on the diagonal.
+func SchurEigenvalues(t *linalg.Matrix) []complex128 {
+	res := make([]complex128, 0, t.Rows)
+	for i := 0; i < t.Rows; i++ {
+		if i+1 == t.Rows || t.Get(i+1, i) == 0 {
+			res = append(res, complex(t.Get(i, i), 0))
+			continue
+		}
+		a, b := t.Get(i, i), t.Get(i, i+1)
+		c, d := t.Get(i+1, i), t.Get(i+1, i+1)
+		mean := (a + d) / 2
+		disc := (a-d)*(a-d)/4 + b*c
+		if disc >= 0 {
+			root := math.Sqrt(disc)
+			res = append(res, complex(mean+root, 0), complex(mean-root, 0))
+		} else {
+			root := math.Sqrt(-disc)
+			res = append(res, complex(mean, root), complex(mean, -root))
+		}
+		i++
+	}
+	return res
+}
+
+// francisStep performs one implicit double-shift QR
+// step on the active block t[lo:hi+1, lo:hi+1],
+// accumulating the transformations into q.
+//
+// If exceptional is true, ad hoc shifts are used to
+// break out of cycles.
+func francisStep(q, t *linalg.Matrix, lo, hi int, exceptional bool) {
+	n := t.Rows
+
+	var shiftSum, shiftProd float64
+	if exceptional {
+		s := math.Abs(t.Get(hi, hi-1)) + math.Abs(t.Get(hi-1, hi-2))
+		shiftSum = 1.5 * s
+		shiftProd = s * s
+	} else {
+		a, b := t.Get(hi-1, hi-1), t.Get(hi-1, hi)
+		c, d := t.Get(hi, hi-1), t.Get(hi, hi)
+		shiftSum = a + d
+		shiftProd = a*d - b*c
+	}
+
+	h00, h01 := t.Get(lo, lo), t.Get(lo, lo+1)
+	h10, h11 := t.Get(lo+1, lo), t.Get(lo+1, lo+1)
+	x := h00*h00 + h01*h10 - shiftSum*h00 + shiftProd
+	y := h10 * (h00 + h11 - shiftSum)
+	z := h10 * t.Get(lo+2, lo+1)
+
+	for k := lo; k <= hi-2; k++ {
+		v, _ := householderVector(linalg.Vector{x, y, z})
+		if v != nil {
+			colStart := lo
+			if k > lo {
+				colStart = k - 1
+			}
+			reflectRows(t, v, k, colStart, n)
+			rowEnd := k + 4
+			if rowEnd > hi+1 {
+				rowEnd = hi + 1
+			}
+			reflectCols(t, v, k, 0, rowEnd)
+			reflectCols(q, v, k, 0, n)
+		}
+		if k > lo {
+			t.Set(k+1, k-1, 0)
+			t.Set(k+2, k-1, 0)
+		}
+		x = t.Get(k+1, k)
+		y = t.Get(k+2, k)
+		if k < hi-2 {
+			z = t.Get(k+3, k)
+		}
+	}
+
+	v, _ := householderVector(linalg.Vector{x, y})
+	if v != nil {
+		reflectRows(t, v, hi-1, hi-2, n)
+		reflectCols(t, v, hi-1, 0, hi+1)
+		reflectCols(q, v, hi-1, 0, n)
+	}
+	t.Set(hi, hi-2, 0)
+}
+
+// splitSchurBlock triangularizes the 2x2 diagonal
+// block starting at index i if it has real
+// eigenvalues.
+func splitSchurBlock(q, t *linalg.Matrix, i int) {
+	a, b := t.Get(i, i), t.Get(i, i+1)
+	c, d := t.Get(i+1, i), t.Get(i+1, i+1)
+	if c == 0 {
+		return
+	}
+	halfDiff := (a - d) / 2
+	disc := halfDiff*halfDiff + b*c
+	if disc < 0 {
+		return
+	}
+
+	// (lambda-d, c) is an eigenvector for the
+	// eigenvalue lambda, which we pick to avoid
+	// cancellation.
+	shifted := halfDiff + math.Copysign(math.Sqrt(disc), halfDiff)
+	norm := math.Hypot(shifted, c)
+	cos, sin := shifted/norm, c/norm
+
+	n := t.Rows
+	for col := 0; col < n; col++ {
+		x, y := t.Get(i, col), t.Get(i+1, col)
+		t.Set(i, col, cos*x+sin*y)
+		t.Set(i+1, col, -sin*x+cos*y)
+	}
+	for _, m := range []*linalg.Matrix{t, q} {
+		for row := 0; row < n; row++ {
+			x, y := m.Get(row, i), m.Get(row, i+1)
+			m.Set(row, i, cos*x+sin*y)
+			m.Set(row, i+1, -sin*x+cos*y)
+		}
+	}
+	t.Set(i+1, i, 0)
+}
+
+// isNegligible checks if a subdiagonal entry is small
+// compared to its neighboring diagonal entries, or to
+// the norm of the matrix if those entries are zero.
+func isNegligible(sub, diag1, diag2, norm float64) bool {
+	epsilon := math.Nextafter(1, 2) - 1
+	scale := math.Abs(diag1) + math.Abs(diag2)
+	if scale == 0 {
+		scale = norm
+	}
+	return math.Abs(sub) <= epsilon*scale
+}
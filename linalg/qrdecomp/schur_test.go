@@ -0,0 +1,153 @@
+package qrdecomp
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestHessenberg(t *testing.T) {
+	for _, m := range []*linalg.Matrix{test4x4Matrix, testSingularMatrix, randomMatrix(10)} {
+		q, h := Hessenberg(m)
+		verifySimilarity(t, m, q, h)
+		for i := 0; i < h.Rows; i++ {
+			for j := 0; j+1 < i; j++ {
+				if h.Get(i, j) != 0 {
+					t.Fatal("not upper Hessenberg:", h)
+				}
+			}
+		}
+	}
+}
+
+func TestSchurRandom(t *testing.T) {
+	for _, size := range []int{1, 2, 3, 10, 30} {
+		m := randomMatrix(size)
+		q, tMat, err := Schur(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifySimilarity(t, m, q, tMat)
+		verifyQuasiTriangular(t, tMat)
+	}
+}
+
+func TestSchurKnownEigenvalues(t *testing.T) {
+	// The companion matrix of (x-1)(x-2)(x-3)(x^2+1).
+	m := &linalg.Matrix{
+		Rows: 5,
+		Cols: 5,
+		Data: []float64{
+			0, 0, 0, 0, 6,
+			1, 0, 0, 0, -11,
+			0, 1, 0, 0, 12,
+			0, 0, 1, 0, -12,
+			0, 0, 0, 1, 6,
+		},
+	}
+	q, tMat, err := Schur(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifySimilarity(t, m, q, tMat)
+	verifyQuasiTriangular(t, tMat)
+
+	eigs := SchurEigenvalues(tMat)
+	sort.Slice(eigs, func(i, j int) bool {
+		if real(eigs[i]) != real(eigs[j]) {
+			return real(eigs[i]) < real(eigs[j])
+		}
+		return imag(eigs[i]) < imag(eigs[j])
+	})
+	expected := []complex128{-1i, 1i, 1, 2, 3}
+	for i, x := range expected {
+		if cmplx.Abs(eigs[i]-x) > 1e-8 {
+			t.Fatal("expected eigenvalues", expected, "but got", eigs)
+		}
+	}
+}
+
+func TestSchurSymmetric(t *testing.T) {
+	m := randomMatrix(8)
+	m = m.Add(m.Transpose())
+	q, tMat, err := Schur(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifySimilarity(t, m, q, tMat)
+	for i := 0; i < tMat.Rows; i++ {
+		for j := 0; j < tMat.Cols; j++ {
+			if i != j && math.Abs(tMat.Get(i, j)) > 1e-8 {
+				t.Fatal("expected diagonal matrix but got", tMat)
+			}
+		}
+	}
+}
+
+func TestSchurDegenerate(t *testing.T) {
+	jordan := &linalg.Matrix{
+		Rows: 3,
+		Cols: 3,
+		Data: []float64{
+			0, 1, 0,
+			0, 0, 1,
+			0, 0, 0,
+		},
+	}
+	for _, m := range []*linalg.Matrix{linalg.NewMatrix(4, 4), linalg.NewMatrixIdentity(4),
+		jordan, testSingularMatrix} {
+		q, tMat, err := Schur(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifySimilarity(t, m, q, tMat)
+		verifyQuasiTriangular(t, tMat)
+	}
+}
+
+func BenchmarkSchur50x50(b *testing.B) {
+	m := randomMatrix(50)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Schur(m)
+	}
+}
+
+func verifySimilarity(t *testing.T, m, q, similar *linalg.Matrix) {
+	if diff := matrixDifference(q.Mul(similar).Mul(q.Transpose()), m); diff > 1e-8 {
+		t.Error("bad product with error", diff)
+	}
+	identity := linalg.NewMatrixIdentity(q.Cols)
+	if diff := matrixDifference(q.Transpose().Mul(q), identity); diff > 1e-8 {
+		t.Error("Q is not orthogonal")
+	}
+}
+
+func verifyQuasiTriangular(t *testing.T, m *linalg.Matrix) {
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j+1 < i; j++ {
+			if m.Get(i, j) != 0 {
+				t.Fatal("not quasi-triangular:", m)
+			}
+		}
+	}
+	for i := 0; i+1 < m.Rows; i++ {
+		if m.Get(i+1, i) == 0 {
+			continue
+		}
+		if i+2 < m.Rows && m.Get(i+2, i+1) != 0 {
+			t.Fatal("overlapping blocks:", m)
+		}
+		eigs := SchurEigenvalues(&linalg.Matrix{
+			Rows: 2,
+			Cols: 2,
+			Data: []float64{m.Get(i, i), m.Get(i, i+1), m.Get(i+1, i), m.Get(i+1, i+1)},
+		})
+		if imag(eigs[0]) == 0 {
+			t.Fatal("block with real eigenvalues was not split:", eigs)
+		}
+	}
+}
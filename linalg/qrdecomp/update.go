@@ -0,0 +1,230 @@
+package qrdecomp
+
+import "github.com/unixpickle/num-analysis/linalg"
+
+// QR stores a full QR decomposition of an MxN matrix
+// which can be updated cheaply as the matrix changes.
+type QR struct {
+	// Q is an MxM orthogonal matrix.
+	Q *linalg.Matrix
+
+	// R is an MxN upper-triangular matrix.
+	R *linalg.Matrix
+}
+
+// NewQR computes the full QR decomposition of m using
+// Givens rotations.
+func NewQR(m *linalg.Matrix) *QR {
+	res := &QR{
+		Q: linalg.NewMatrixIdentity(m.Rows),
+		R: m.Copy(),
+	}
+	for col := 0; col < m.Cols && col < m.Rows; col++ {
+		for row := m.Rows - 1; row > col; row-- {
+			res.rotate(row-1, row, col)
+		}
+	}
+	return res
+}
+
+// AppendRow updates the decomposition to account for a
+// new row at the bottom of the matrix.
+// This takes O(M*N + N^2) time.
+func (q *QR) AppendRow(row linalg.Vector) {
+	rows, cols := q.R.Rows, q.R.Cols
+	if len(row) != cols {
+		panic("dimension mismatch")
+	}
+
+	newR := linalg.NewMatrix(rows+1, cols)
+	copy(newR.Data, q.R.Data)
+	copy(newR.Data[rows*cols:], row)
+	newQ := linalg.NewMatrix(rows+1, rows+1)
+	for i := 0; i < rows; i++ {
+		copy(newQ.Data[i*(rows+1):], q.Q.Data[i*rows:(i+1)*rows])
+	}
+	newQ.Set(rows, rows, 1)
+	q.Q, q.R = newQ, newR
+
+	for col := 0; col < cols && col < rows; col++ {
+		q.rotate(col, rows, col)
+	}
+}
+
+// DeleteRow updates the decomposition to account for
+// the removal of the given row of the matrix.
+// This takes O(M^2 + M*N) time.
+func (q *QR) DeleteRow(index int) {
+	rows, cols := q.R.Rows, q.R.Cols
+	if index < 0 || index >= rows {
+		panic("index out of bounds")
+	}
+
+	// Rotate the deleted row of Q into the first basis
+	// vector, which moves the deleted row of the matrix
+	// into the first row of R.
+	for i := rows - 1; i > 0; i-- {
+		g := NewGivens(i-1, i, q.Q.Get(index, i-1), q.Q.Get(index, i))
+		g.ApplyRows(q.R)
+		g.ApplyCols(q.Q)
+	}
+
+	newQ := linalg.NewMatrix(rows-1, rows-1)
+	for i, dest := 0, 0; i < rows; i++ {
+		if i == index {
+			continue
+		}
+		for j := 1; j < rows; j++ {
+			newQ.Set(dest, j-1, q.Q.Get(i, j))
+		}
+		dest++
+	}
+	newR := &linalg.Matrix{
+		Rows: rows - 1,
+		Cols: cols,
+		Data: append([]float64{}, q.R.Data[cols:]...),
+	}
+	q.Q, q.R = newQ, newR
+}
+
+// AppendCol updates the decomposition to account for a
+// new column on the right side of the matrix.
+// This takes O(M^2) time.
+func (q *QR) AppendCol(col linalg.Vector) {
+	rows, cols := q.R.Rows, q.R.Cols
+	if len(col) != rows {
+		panic("dimension mismatch")
+	}
+	projected := q.Q.Transpose().Mul(linalg.NewMatrixColumn(col))
+
+	newR := linalg.NewMatrix(rows, cols+1)
+	for i := 0; i < rows; i++ {
+		copy(newR.Data[i*(cols+1):], q.R.Data[i*cols:(i+1)*cols])
+		newR.Set(i, cols, projected.Get(i, 0))
+	}
+	q.R = newR
+
+	for row := rows - 1; row > cols; row-- {
+		q.rotate(row-1, row, cols)
+	}
+}
+
+// DeleteCol updates the decomposition to account for
+// the removal of the given column of the matrix.
+// This takes O(M*N + M^2) time.
+func (q *QR) DeleteCol(index int) {
+	rows, cols := q.R.Rows, q.R.Cols
+	if index < 0 || index >= cols {
+		panic("index out of bounds")
+	}
+
+	newR := linalg.NewMatrix(rows, cols-1)
+	for i := 0; i < rows; i++ {
+		for j, dest := 0, 0; j < cols; j++ {
+			if j != index {
+				newR.Set(i, dest, q.R.Get(i, j))
+				dest++
+			}
+		}
+	}
+	q.R = newR
+
+	// Removing the column leaves R upper Hessenberg
+	// to the right of the removed column.
+	for col := index; col < cols-1 && col+1 < rows; col++ {
+		q.rotate(col, col+1, col)
+	}
+}
+
+// RankOneUpdate updates the decomposition to account
+// for adding u*v' to the matrix.
+// This takes O(M^2 + M*N) time.
+func (q *QR) RankOneUpdate(u, v linalg.Vector) {
+	rows, cols := q.R.Rows, q.R.Cols
+	if len(u) != rows || len(v) != cols {
+		panic("dimension mismatch")
+	}
+	w := linalg.Vector(q.Q.Transpose().Mul(linalg.NewMatrixColumn(u)).Data)
+
+	// Rotate w into a multiple of the first basis
+	// vector, which makes R upper Hessenberg.
+	for i := rows - 1; i > 0; i-- {
+		g := NewGivens(i-1, i, w[i-1], w[i])
+		w = g.Apply(w)
+		g.ApplyRows(q.R)
+		g.ApplyCols(q.Q)
+	}
+	for j, x := range v {
+		q.R.Set(0, j, q.R.Get(0, j)+w[0]*x)
+	}
+
+	for col := 0; col < cols && col+1 < rows; col++ {
+		q.rotate(col, col+1, col)
+	}
+}
+
+// Solve finds the least-squares solution to A*x = b,
+// where A is the decomposed matrix.
+// The matrix must have at least as many rows as
+// columns, and it must have full column rank.
+func (q *QR) Solve(b linalg.Vector) linalg.Vector {
+	if q.R.Rows < q.R.Cols {
+		panic("matrix has more columns than rows")
+	}
+	rhs := linalg.Vector(q.Q.Transpose().Mul(linalg.NewMatrixColumn(b)).Data)
+	return solveUpperTriangular(q.R, rhs, q.R.Cols)
+}
+
+// rotate uses a Givens rotation on rows i and j to
+// zero out R[j][col], accumulating the rotation in Q.
+func (q *QR) rotate(i, j, col int) {
+	if q.R.Get(j, col) == 0 {
+		return
+	}
+	g := NewGivens(i, j, q.R.Get(i, col), q.R.Get(j, col))
+	g.ApplyRows(q.R)
+	g.ApplyCols(q.Q)
+	q.R.Set(j, col, 0)
+}
+
+// UpdateR updates the NxN upper-triangular factor r of
+// a tall matrix A in place, so that it becomes the
+// factor for A with row appended to it.
+// Only R is updated, so this takes O(N^2) time no
+// matter how many rows A has.
+//
+// This is useful for recursive least squares: if the
+// right-hand side of each equation is appended to its
+// row, then the last column of r holds the part of
+// Q'*b needed to solve for the coefficients.
+func UpdateR(r *linalg.Matrix, row linalg.Vector) {
+	if !r.Square() || len(row) != r.Cols {
+		panic("dimension mismatch")
+	}
+	row = row.Copy()
+	for col := range row {
+		if row[col] == 0 {
+			continue
+		}
+		g := NewGivens(0, 1, r.Get(col, col), row[col])
+		for j := col; j < r.Cols; j++ {
+			x, y := r.Get(col, j), row[j]
+			r.Set(col, j, g.Cos*x+g.Sin*y)
+			row[j] = g.Cos*y - g.Sin*x
+		}
+	}
+}
+
+// solveUpperTriangular solves the system given by the
+// top-left nxn block of r and the first n entries of b.
+func solveUpperTriangular(r *linalg.Matrix, b linalg.Vector, n int) linalg.Vector {
+	res := make(linalg.Vector, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := b[i]
+		for j := i + 1; j < n; j++ {
+			sum -= r.Get(i, j) * res[j]
+		}
+		res[i] = sum / r.Get(i, i)
+	}
+	return res
+}
@@ -0,0 +1,186 @@
+package qrdecomp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestGivens(t *testing.T) {
+	v := linalg.Vector{1, 3, -2, 4}
+	g := NewGivens(1, 3, v[1], v[3])
+	res := g.Apply(v)
+	expected := linalg.Vector{1, 5, -2, 0}
+	for i, x := range expected {
+		if math.Abs(res[i]-x) > 1e-10 {
+			t.Fatal("expected", expected, "but got", res)
+		}
+	}
+}
+
+func TestNewQR(t *testing.T) {
+	for _, m := range []*linalg.Matrix{test5x3Matrix, test4x4Matrix, testSingularMatrix,
+		test5x3Matrix.Transpose()} {
+		verifyQR(t, m, NewQR(m))
+	}
+}
+
+func TestQRAppendRow(t *testing.T) {
+	m := test5x3Matrix.Copy()
+	qr := NewQR(m)
+	for i := 0; i < 3; i++ {
+		row := linalg.RandVector(m.Cols)
+		qr.AppendRow(row)
+		m = appendRow(m, row)
+		verifyQR(t, m, qr)
+	}
+
+	// Grow a wide matrix until it becomes tall.
+	m = test5x3Matrix.Transpose()
+	qr = NewQR(m)
+	for i := 0; i < 4; i++ {
+		row := linalg.RandVector(m.Cols)
+		qr.AppendRow(row)
+		m = appendRow(m, row)
+		verifyQR(t, m, qr)
+	}
+}
+
+func TestQRDeleteRow(t *testing.T) {
+	m := randomRectMatrix(7, 4)
+	qr := NewQR(m)
+	for _, index := range []int{6, 0, 2, 1, 0} {
+		qr.DeleteRow(index)
+		m = deleteRow(m, index)
+		verifyQR(t, m, qr)
+	}
+}
+
+func TestQRAppendCol(t *testing.T) {
+	m := randomRectMatrix(5, 1)
+	qr := NewQR(m)
+	for i := 0; i < 6; i++ {
+		col := linalg.RandVector(m.Rows)
+		qr.AppendCol(col)
+		m = appendRow(m.Transpose(), col).Transpose()
+		verifyQR(t, m, qr)
+	}
+}
+
+func TestQRDeleteCol(t *testing.T) {
+	m := randomRectMatrix(4, 7)
+	qr := NewQR(m)
+	for _, index := range []int{0, 5, 2, 3, 0} {
+		qr.DeleteCol(index)
+		m = deleteRow(m.Transpose(), index).Transpose()
+		verifyQR(t, m, qr)
+	}
+}
+
+func TestQRRankOneUpdate(t *testing.T) {
+	for _, m := range []*linalg.Matrix{test5x3Matrix, test4x4Matrix, test5x3Matrix.Transpose()} {
+		m = m.Copy()
+		qr := NewQR(m)
+		for i := 0; i < 3; i++ {
+			u := linalg.RandVector(m.Rows)
+			v := linalg.RandVector(m.Cols)
+			qr.RankOneUpdate(u, v)
+			m.Add(linalg.NewMatrixColumn(u).Mul(linalg.NewMatrixColumn(v).Transpose()))
+			verifyQR(t, m, qr)
+		}
+	}
+}
+
+func TestQRSolve(t *testing.T) {
+	m := randomRectMatrix(8, 3)
+	solution := linalg.Vector{1, -2, 3}
+	b := linalg.Vector(m.Mul(linalg.NewMatrixColumn(solution)).Data)
+
+	qr := NewQR(m)
+	if diff := qr.Solve(b).Copy().Scale(-1).Add(solution).MaxAbs(); diff > 1e-8 {
+		t.Error("bad solution with error", diff)
+	}
+}
+
+func TestUpdateR(t *testing.T) {
+	// Fit y = 2 - x + 3x^2 one sample at a time, storing
+	// the right-hand side in the last column of R.
+	r := linalg.NewMatrix(4, 4)
+	for i := 0; i < 20; i++ {
+		x := rand.Float64()*4 - 2
+		y := 2 - x + 3*x*x
+		UpdateR(r, linalg.Vector{1, x, x * x, y})
+	}
+
+	coeffs := solveUpperTriangular(r, r.Col(3), 3)
+	expected := linalg.Vector{2, -1, 3}
+	for i, x := range expected {
+		if math.Abs(coeffs[i]-x) > 1e-8 {
+			t.Fatal("expected", expected, "but got", coeffs)
+		}
+	}
+
+	for i := 0; i < r.Rows; i++ {
+		for j := 0; j < i; j++ {
+			if r.Get(i, j) != 0 {
+				t.Fatal("R is not upper-triangular:", r)
+			}
+		}
+	}
+}
+
+func BenchmarkQRAppendRow100x100(b *testing.B) {
+	m := randomMatrix(100)
+	row := linalg.RandVector(100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		qr := NewQR(m)
+		b.StartTimer()
+		qr.AppendRow(row)
+	}
+}
+
+func verifyQR(t *testing.T, m *linalg.Matrix, qr *QR) {
+	if qr.Q.Rows != m.Rows || qr.Q.Cols != m.Rows || qr.R.Rows != m.Rows || qr.R.Cols != m.Cols {
+		t.Fatal("bad dimensions for Q and R:", qr.Q.Rows, qr.Q.Cols, qr.R.Rows, qr.R.Cols)
+	}
+	if diff := matrixDifference(qr.Q.Mul(qr.R), m); diff > 1e-8 {
+		t.Fatal("bad product with error", diff)
+	}
+	identity := linalg.NewMatrixIdentity(m.Rows)
+	if diff := matrixDifference(qr.Q.Transpose().Mul(qr.Q), identity); diff > 1e-8 {
+		t.Fatal("Q is not orthogonal")
+	}
+	for i := 0; i < qr.R.Rows; i++ {
+		for j := 0; j < i && j < qr.R.Cols; j++ {
+			if qr.R.Get(i, j) != 0 {
+				t.Fatal("R is not upper-triangular:", qr.R)
+			}
+		}
+	}
+}
+
+func randomRectMatrix(rows, cols int) *linalg.Matrix {
+	res := linalg.NewMatrix(rows, cols)
+	for i := range res.Data {
+		res.Data[i] = rand.Float64()
+	}
+	return res
+}
+
+func appendRow(m *linalg.Matrix, row linalg.Vector) *linalg.Matrix {
+	return &linalg.Matrix{
+		Rows: m.Rows + 1,
+		Cols: m.Cols,
+		Data: append(append([]float64{}, m.Data...), row...),
+	}
+}
+
+func deleteRow(m *linalg.Matrix, index int) *linalg.Matrix {
+	data := append([]float64{}, m.Data[:index*m.Cols]...)
+	data = append(data, m.Data[(index+1)*m.Cols:]...)
+	return &linalg.Matrix{Rows: m.Rows - 1, Cols: m.Cols, Data: data}
+}
@@ -0,0 +1,84 @@
+package sylvester
+
+import (
+	"github.com/unixpickle/num-analysis/conjgrad"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// Operator is the linear transformation X -> AX + XB
+// on MxN matrices, where A and B are symmetric.
+// Matrices are flattened into vectors in row-major
+// order, just like the Data field of a linalg.Matrix.
+//
+// Operator is symmetric, and if every sum of an
+// eigenvalue of A and an eigenvalue of B is positive,
+// then it is also positive definite.
+type Operator struct {
+	A conjgrad.LinTran
+	B conjgrad.LinTran
+}
+
+func (o *Operator) Dim() int {
+	return o.A.Dim() * o.B.Dim()
+}
+
+func (o *Operator) Apply(v linalg.Vector) linalg.Vector {
+	rows, cols := o.A.Dim(), o.B.Dim()
+	if len(v) != rows*cols {
+		panic("dimension mismatch")
+	}
+	res := make(linalg.Vector, len(v))
+
+	column := make(linalg.Vector, rows)
+	for col := 0; col < cols; col++ {
+		for row := range column {
+			column[row] = v[row*cols+col]
+		}
+		for row, x := range o.A.Apply(column) {
+			res[row*cols+col] = x
+		}
+	}
+
+	// Since B is symmetric, each row of XB is B times
+	// the corresponding row of X.
+	for row := 0; row < rows; row++ {
+		product := o.B.Apply(v[row*cols : (row+1)*cols])
+		res[row*cols : (row+1)*cols].Add(product)
+	}
+
+	return res
+}
+
+// SolveSymmetric solves a*x + x*b = c for x using the
+// conjugate gradient method on an Operator.
+//
+// This requires a and b to be symmetric, and every sum
+// of an eigenvalue of a and an eigenvalue of b must be
+// positive.
+// Since a and b need not be stored explicitly, this is
+// suitable for large, sparse problems which Solve
+// cannot handle.
+//
+// The opts argument is passed to conjgrad.Solve, and
+// the result of the solve is returned along with x.
+func SolveSymmetric(a, b conjgrad.LinTran, c *linalg.Matrix,
+	opts *conjgrad.Options) (*linalg.Matrix, *conjgrad.Result) {
+	if c.Rows != a.Dim() || c.Cols != b.Dim() {
+		panic("dimension mismatch")
+	}
+	result := conjgrad.Solve(&Operator{A: a, B: b}, c.Data, opts)
+	x := &linalg.Matrix{
+		Rows: c.Rows,
+		Cols: c.Cols,
+		Data: result.Solution.Copy(),
+	}
+	return x, result
+}
+
+// LyapunovSymmetric solves a*x + x*a = q for x, just
+// like SolveSymmetric.
+// This requires a to be symmetric positive-definite.
+func LyapunovSymmetric(a conjgrad.LinTran, q *linalg.Matrix,
+	opts *conjgrad.Options) (*linalg.Matrix, *conjgrad.Result) {
+	return SolveSymmetric(a, a, q, opts)
+}
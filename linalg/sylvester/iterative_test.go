@@ -0,0 +1,65 @@
+package sylvester
+
+import (
+	"testing"
+
+	"github.com/unixpickle/num-analysis/conjgrad"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestSolveSymmetric(t *testing.T) {
+	a := laplacian(12)
+	b := randomPosDef(5)
+	c := randomMatrix(12, 5)
+
+	x, result := SolveSymmetric(a, conjgrad.MatLinTran{M: b}, c,
+		&conjgrad.Options{Prec: 1e-10})
+	if result.Reason != conjgrad.Converged {
+		t.Fatal("unexpected reason:", result.Reason)
+	}
+	if diff := maxDifference(a.Dense().Mul(x).Add(x.Mul(b)), c); diff > 1e-9 {
+		t.Error("residual too large:", diff)
+	}
+
+	direct, err := Solve(a.Dense(), b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := maxDifference(direct, x); diff > 1e-8 {
+		t.Error("iterative and direct solutions differ by", diff)
+	}
+}
+
+func TestLyapunovSymmetric(t *testing.T) {
+	a := laplacian(10)
+	q := randomMatrix(10, 10)
+	q = q.Add(q.Transpose())
+	x, result := LyapunovSymmetric(a, q, &conjgrad.Options{Prec: 1e-10})
+	if result.Reason != conjgrad.Converged {
+		t.Fatal("unexpected reason:", result.Reason)
+	}
+	if diff := maxDifference(a.Dense().Mul(x).Add(x.Mul(a.Dense())), q); diff > 1e-9 {
+		t.Error("residual too large:", diff)
+	}
+}
+
+func laplacian(size int) *conjgrad.SparseMatrix {
+	res := conjgrad.NewSparseMatrix(size)
+	for i := 0; i < size; i++ {
+		res.Set(i, i, 2)
+		if i > 0 {
+			res.Set(i, i-1, -1)
+			res.Set(i-1, i, -1)
+		}
+	}
+	return res
+}
+
+func randomPosDef(size int) *linalg.Matrix {
+	m := randomMatrix(size, size)
+	res := m.Mul(m.Transpose())
+	for i := 0; i < size; i++ {
+		res.Set(i, i, res.Get(i, i)+1)
+	}
+	return res
+}
@@ -0,0 +1,179 @@
+// Package sylvester solves the Sylvester equation
+// AX + XB = C and the Lyapunov equation AX + XA' = Q.
+package sylvester
+
+import (
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+	"github.com/unixpickle/num-analysis/linalg/qrdecomp"
+)
+
+// ErrSingular is returned when A and -B share an
+// eigenvalue, in which case the Sylvester equation
+// has no unique solution.
+var ErrSingular = errors.New("equation has no unique solution")
+
+// Solve solves a*x + x*b = c for x using the
+// Bartels-Stewart algorithm, where a is MxM, b is NxN,
+// and c is MxN.
+//
+// Both a and b are reduced to real Schur form, so this
+// takes O(M^3 + N^3) time.
+func Solve(a, b, c *linalg.Matrix) (*linalg.Matrix, error) {
+	if !a.Square() || !b.Square() || c.Rows != a.Rows || c.Cols != b.Rows {
+		panic("dimension mismatch")
+	}
+	u, s, err := qrdecomp.Schur(a)
+	if err != nil {
+		return nil, err
+	}
+	v, t, err := qrdecomp.Schur(b)
+	if err != nil {
+		return nil, err
+	}
+	f := u.Transpose().Mul(c).Mul(v)
+	y, err := solveQuasiTriangular(s, t, f)
+	if err != nil {
+		return nil, err
+	}
+	return u.Mul(y).Mul(v.Transpose()), nil
+}
+
+// Lyapunov solves a*x + x*a' = q for x.
+//
+// If q is symmetric, then so is the solution.
+func Lyapunov(a, q *linalg.Matrix) (*linalg.Matrix, error) {
+	x, err := Solve(a, a.Transpose(), q)
+	if err != nil {
+		return nil, err
+	}
+	if isSymmetric(q) {
+		symmetrize(x)
+	}
+	return x, nil
+}
+
+// solveQuasiTriangular solves s*y + y*t = f for y,
+// where s and t are quasi-upper-triangular.
+func solveQuasiTriangular(s, t, f *linalg.Matrix) (*linalg.Matrix, error) {
+	scale := maxAbs(s) + maxAbs(t)
+	rowBlocks := diagonalBlocks(s)
+	colBlocks := diagonalBlocks(t)
+	y := linalg.NewMatrix(f.Rows, f.Cols)
+
+	for _, colBlock := range colBlocks {
+		j0, q := colBlock[0], colBlock[1]
+		for bi := len(rowBlocks) - 1; bi >= 0; bi-- {
+			i0, p := rowBlocks[bi][0], rowBlocks[bi][1]
+
+			// Find the right-hand side for this block
+			// using the parts of y which are known.
+			rhs := make(linalg.Vector, p*q)
+			for r := 0; r < p; r++ {
+				for c := 0; c < q; c++ {
+					row, col := i0+r, j0+c
+					sum := f.Get(row, col)
+					for k := 0; k < j0; k++ {
+						sum -= y.Get(row, k) * t.Get(k, col)
+					}
+					for l := i0 + p; l < s.Cols; l++ {
+						sum -= s.Get(row, l) * y.Get(l, col)
+					}
+					rhs[r*q+c] = sum
+				}
+			}
+
+			system := linalg.NewMatrix(p*q, p*q)
+			for r := 0; r < p; r++ {
+				for c := 0; c < q; c++ {
+					for k := 0; k < p; k++ {
+						val := system.Get(r*q+c, k*q+c) + s.Get(i0+r, i0+k)
+						system.Set(r*q+c, k*q+c, val)
+					}
+					for k := 0; k < q; k++ {
+						val := system.Get(r*q+c, r*q+k) + t.Get(j0+k, j0+c)
+						system.Set(r*q+c, r*q+k, val)
+					}
+				}
+			}
+			solution, err := solveSmall(system, rhs, scale)
+			if err != nil {
+				return nil, err
+			}
+			for r := 0; r < p; r++ {
+				for c := 0; c < q; c++ {
+					y.Set(i0+r, j0+c, solution[r*q+c])
+				}
+			}
+		}
+	}
+
+	return y, nil
+}
+
+// solveSmall solves a small linear system, failing if
+// it is singular relative to the given scale.
+func solveSmall(m *linalg.Matrix, b linalg.Vector, scale float64) (linalg.Vector, error) {
+	epsilon := math.Nextafter(1, 2) - 1
+	lu := ludecomp.Decompose(m)
+	if lu.PivotScale() < epsilon {
+		return nil, ErrSingular
+	}
+	for i := 0; i < lu.LU.Rows; i++ {
+		if math.Abs(lu.LU.Get(i, i)) <= epsilon*scale {
+			return nil, ErrSingular
+		}
+	}
+	return lu.Solve(b), nil
+}
+
+// diagonalBlocks finds the 1x1 and 2x2 diagonal
+// blocks of a quasi-upper-triangular matrix, returning
+// the start index and size of each one.
+func diagonalBlocks(m *linalg.Matrix) [][2]int {
+	var res [][2]int
+	for i := 0; i < m.Rows; i++ {
+		if i+1 < m.Rows && m.Get(i+1, i) != 0 {
+			res = append(res, [2]int{i, 2})
+			i++
+		} else {
+			res = append(res, [2]int{i, 1})
+		}
+	}
+	return res
+}
+
+func maxAbs(m *linalg.Matrix) float64 {
+	var res float64
+	for _, x := range m.Data {
+		res = math.Max(res, math.Abs(x))
+	}
+	return res
+}
+
+func isSymmetric(m *linalg.Matrix) bool {
+	if !m.Square() {
+		return false
+	}
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j < i; j++ {
+			if m.Get(i, j) != m.Get(j, i) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func symmetrize(m *linalg.Matrix) {
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j < i; j++ {
+			mean := (m.Get(i, j) + m.Get(j, i)) / 2
+			m.Set(i, j, mean)
+			m.Set(j, i, mean)
+		}
+	}
+}
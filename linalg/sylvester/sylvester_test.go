@@ -0,0 +1,70 @@
+package sylvester
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestSolve(t *testing.T) {
+	for _, size := range [][2]int{{1, 1}, {3, 5}, {6, 4}, {12, 12}} {
+		a := randomMatrix(size[0], size[0])
+		b := randomMatrix(size[1], size[1])
+		c := randomMatrix(size[0], size[1])
+		x, err := Solve(a, b, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := maxDifference(a.Mul(x).Add(x.Mul(b)), c); diff > 1e-8 {
+			t.Errorf("size %v: residual %e", size, diff)
+		}
+	}
+}
+
+func TestSolveSingular(t *testing.T) {
+	// a and -b share the eigenvalue 2.
+	a := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{2, 1, 0, 3}}
+	b := &linalg.Matrix{Rows: 2, Cols: 2, Data: []float64{-2, 0, 5, 1}}
+	if _, err := Solve(a, b, randomMatrix(2, 2)); err != ErrSingular {
+		t.Error("expected ErrSingular but got", err)
+	}
+}
+
+func TestLyapunov(t *testing.T) {
+	// A stable matrix with complex eigenvalues.
+	a := randomMatrix(7, 7)
+	for i := 0; i < 7; i++ {
+		a.Set(i, i, a.Get(i, i)-4)
+	}
+	q := randomMatrix(7, 7)
+	q = q.Mul(q.Transpose()).Scale(-1)
+
+	x, err := Lyapunov(a, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := maxDifference(a.Mul(x).Add(x.Mul(a.Transpose())), q); diff > 1e-8 {
+		t.Error("residual too large:", diff)
+	}
+	if !isSymmetric(x) {
+		t.Error("solution is not symmetric")
+	}
+}
+
+func randomMatrix(rows, cols int) *linalg.Matrix {
+	res := linalg.NewMatrix(rows, cols)
+	for i := range res.Data {
+		res.Data[i] = rand.NormFloat64()
+	}
+	return res
+}
+
+func maxDifference(m1, m2 *linalg.Matrix) float64 {
+	var res float64
+	for i, x := range m1.Data {
+		res = math.Max(res, math.Abs(x-m2.Data[i]))
+	}
+	return res
+}
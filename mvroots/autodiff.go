@@ -0,0 +1,64 @@
+package mvroots
+
+import (
+	"github.com/unixpickle/num-analysis/autodiff"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A NumVecFunc is a vector-valued function written in
+// terms of autodiff.Num values.
+type NumVecFunc func(x []autodiff.Num) []autodiff.Num
+
+// An AutoAdapter turns a NumVecFunc F into a Func,
+// computing the Jacobian with automatic
+// differentiation.
+//
+// Each input to F has a gradient of size N, so any
+// constants in F should be created with
+// autodiff.NewNum(c, N).
+type AutoAdapter struct {
+	// N is the dimensionality of F.
+	N int
+	F NumVecFunc
+}
+
+// Dim returns a.N.
+func (a AutoAdapter) Dim() int {
+	return a.N
+}
+
+// Eval evaluates F.
+func (a AutoAdapter) Eval(vec linalg.Vector) linalg.Vector {
+	out := a.apply(vec)
+	res := make(linalg.Vector, len(out))
+	for i, x := range out {
+		res[i] = x.Value
+	}
+	return res
+}
+
+// Jacobian computes the Jacobian of F by evaluating
+// it with one gradient component per input.
+func (a AutoAdapter) Jacobian(vec linalg.Vector) *linalg.Matrix {
+	out := a.apply(vec)
+	res := linalg.NewMatrix(a.N, a.N)
+	for i, x := range out {
+		copy(res.Data[i*a.N:(i+1)*a.N], x.Gradient)
+	}
+	return res
+}
+
+func (a AutoAdapter) apply(vec linalg.Vector) []autodiff.Num {
+	if len(vec) != a.N {
+		panic("wrong dimensionality")
+	}
+	in := make([]autodiff.Num, a.N)
+	for i, x := range vec {
+		in[i] = autodiff.NewNumVar(x, a.N, i)
+	}
+	out := a.F(in)
+	if len(out) != a.N {
+		panic("wrong dimensionality")
+	}
+	return out
+}
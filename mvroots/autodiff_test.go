@@ -0,0 +1,47 @@
+package mvroots
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/autodiff"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestAutoAdapter(t *testing.T) {
+	f := AutoAdapter{
+		N: 2,
+		F: func(x []autodiff.Num) []autodiff.Num {
+			four := autodiff.NewNum(4, 2)
+			return []autodiff.Num{
+				x[0].Mul(x[0]).Add(x[1].Mul(x[1])).Sub(four),
+				x[0].Sub(x[1]),
+			}
+		},
+	}
+	for i := 0; i < 5; i++ {
+		x := linalg.RandVector(2)
+		actual := f.Eval(x)
+		expected := circleLineFunc{}.Eval(x)
+		if actual.Copy().Add(expected.Scale(-1)).MaxAbs() > 1e-12 {
+			t.Error("bad value at", x, ":", actual)
+		}
+		actualJac := f.Jacobian(x)
+		expectedJac := circleLineFunc{}.Jacobian(x)
+		for j, a := range actualJac.Data {
+			if math.Abs(a-expectedJac.Data[j]) > 1e-12 {
+				t.Error("bad Jacobian at", x, ":", actualJac.Data)
+				break
+			}
+		}
+	}
+
+	root, err := NewtonContext(context.Background(), f, linalg.Vector{1, 2}, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(root[0]-math.Sqrt2) > 1e-10 || math.Abs(root[1]-math.Sqrt2) > 1e-10 {
+		t.Error("bad root", root)
+	}
+}
@@ -0,0 +1,92 @@
+package mvroots
+
+import (
+	"context"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// BroydenIterator uses Broyden's quasi-Newton method to
+// search for a root of a function.
+//
+// The Jacobian is only computed at the starting point.
+// After that, an approximation of its inverse is kept
+// up to date with rank-one updates, so each step needs
+// a single function evaluation and no linear solves.
+type BroydenIterator struct {
+	function Func
+	guess    linalg.Vector
+	value    linalg.Vector
+	inverse  *linalg.Matrix
+}
+
+// NewBroydenIterator creates a BroydenIterator with a
+// given input vector at which to start the search.
+//
+// If the Jacobian at start is singular, the identity
+// is used as the initial inverse Jacobian.
+func NewBroydenIterator(f Func, start linalg.Vector) *BroydenIterator {
+	n := f.Dim()
+	inverse := linalg.NewMatrixIdentity(n)
+	jacobian := f.Jacobian(start)
+	if _, ok := newtonDirection(jacobian, make(linalg.Vector, n)); ok {
+		for i := 0; i < n; i++ {
+			unit := make(linalg.Vector, n)
+			unit[i] = -1
+			col, _ := newtonDirection(jacobian, unit)
+			for j, x := range col {
+				inverse.Set(j, i, x)
+			}
+		}
+	}
+	return &BroydenIterator{
+		function: f,
+		guess:    start,
+		value:    f.Eval(start),
+		inverse:  inverse,
+	}
+}
+
+// Step performs one root-finding iteration.
+// It returns the Euclidean distance between
+// the previous guess and the current guess.
+func (b *BroydenIterator) Step() float64 {
+	if isZeroVector(b.value) {
+		return 0
+	}
+
+	step := mulVec(b.inverse, b.value).Scale(-1)
+	newGuess := step.Copy().Add(b.guess)
+	newValue := b.function.Eval(newGuess)
+	valueDiff := newValue.Copy().Add(b.value.Copy().Scale(-1))
+
+	// Sherman-Morrison update so that the new inverse
+	// maps valueDiff to step.
+	invDiff := mulVec(b.inverse, valueDiff)
+	denom := step.Dot(invDiff)
+	if denom != 0 {
+		stepInv := mulVec(b.inverse.Transpose(), step)
+		correction := step.Copy().Add(invDiff.Scale(-1)).Scale(1 / denom)
+		for i, c := range correction {
+			for j, s := range stepInv {
+				b.inverse.Set(i, j, b.inverse.Get(i, j)+c*s)
+			}
+		}
+	}
+
+	b.guess = newGuess
+	b.value = newValue
+	return step.Mag()
+}
+
+// Guess returns the current approximate root.
+func (b *BroydenIterator) Guess() linalg.Vector {
+	return b.guess
+}
+
+// BroydenContext is like NewtonContext, but it uses a
+// BroydenIterator.
+func BroydenContext(ctx context.Context, f Func, start linalg.Vector,
+	prec float64) (linalg.Vector, error) {
+	return searchContext(ctx, f, NewBroydenIterator(f, start.Copy()), start, prec)
+}
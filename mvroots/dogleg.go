@@ -0,0 +1,125 @@
+package mvroots
+
+import (
+	"context"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// DoglegIterator searches for a root of a function F
+// by minimizing ||F||^2 with Powell's dogleg
+// trust-region method.
+//
+// Each step stays within a trust radius, moving along
+// a path from the steepest descent (Cauchy) step to the
+// full Newton step.
+// The radius grows when the linear model of F predicts
+// the decrease in ||F||^2 well, and shrinks otherwise.
+type DoglegIterator struct {
+	function Func
+	guess    linalg.Vector
+	radius   float64
+}
+
+// NewDoglegIterator creates a DoglegIterator with a
+// given input vector at which to start the search and
+// an initial trust radius.
+func NewDoglegIterator(f Func, start linalg.Vector, radius float64) *DoglegIterator {
+	if radius <= 0 {
+		panic("trust radius must be positive")
+	}
+	return &DoglegIterator{function: f, guess: start, radius: radius}
+}
+
+// Step performs one root-finding iteration.
+// It returns the Euclidean distance between
+// the previous guess and the current guess.
+//
+// Trial steps that do not decrease ||F||^2 shrink the
+// trust radius and are retried, so a return value of 0
+// means that the iteration has stalled.
+func (d *DoglegIterator) Step() float64 {
+	value := d.function.Eval(d.guess)
+	if isZeroVector(value) {
+		return 0
+	}
+	normSq := value.Dot(value)
+
+	jacobian := d.function.Jacobian(d.guess)
+	gradient := mulVec(jacobian.Transpose(), value)
+	jg := mulVec(jacobian, gradient)
+	if jg.Dot(jg) == 0 {
+		return 0
+	}
+	cauchy := gradient.Copy().Scale(-gradient.Dot(gradient) / jg.Dot(jg))
+	newton, ok := newtonDirection(jacobian, value)
+
+	minRadius := (math.Nextafter(1, 2) - 1) * math.Max(1, d.guess.Mag())
+	for d.radius > minRadius {
+		step := doglegStep(cauchy, newton, ok, d.radius)
+		stepSize := step.Mag()
+
+		modelValue := mulVec(jacobian, step).Add(value)
+		predicted := normSq - modelValue.Dot(modelValue)
+		if !(predicted > 0) {
+			return 0
+		}
+
+		candidate := step.Copy().Add(d.guess)
+		newValue := d.function.Eval(candidate)
+		actual := math.Inf(-1)
+		if isFiniteVector(newValue) {
+			actual = normSq - newValue.Dot(newValue)
+		}
+
+		ratio := actual / predicted
+		if ratio < 0.25 {
+			d.radius = stepSize / 4
+		} else if ratio > 0.75 && stepSize > 0.99*d.radius {
+			d.radius *= 2
+		}
+		if ratio > armijoFactor {
+			d.guess = candidate
+			return stepSize
+		}
+	}
+	return 0
+}
+
+// Guess returns the current approximate root.
+func (d *DoglegIterator) Guess() linalg.Vector {
+	return d.guess
+}
+
+// Radius returns the current trust radius.
+func (d *DoglegIterator) Radius() float64 {
+	return d.radius
+}
+
+// DoglegContext is like NewtonContext, but it uses a
+// DoglegIterator with an initial trust radius of 1.
+func DoglegContext(ctx context.Context, f Func, start linalg.Vector,
+	prec float64) (linalg.Vector, error) {
+	return searchContext(ctx, f, NewDoglegIterator(f, start.Copy(), 1), start, prec)
+}
+
+// doglegStep finds the point on the dogleg path which
+// is furthest along without leaving the trust radius.
+func doglegStep(cauchy, newton linalg.Vector, hasNewton bool, radius float64) linalg.Vector {
+	if hasNewton && newton.Mag() <= radius {
+		return newton.Copy()
+	}
+	cauchyMag := cauchy.Mag()
+	if !hasNewton || cauchyMag >= radius {
+		return cauchy.Copy().Scale(math.Min(1, radius/cauchyMag))
+	}
+
+	// Solve ||cauchy + t*(newton-cauchy)|| = radius.
+	diff := newton.Copy().Add(cauchy.Copy().Scale(-1))
+	a := diff.Dot(diff)
+	b := 2 * cauchy.Dot(diff)
+	c := cauchyMag*cauchyMag - radius*radius
+	t := (-b + math.Sqrt(b*b-4*a*c)) / (2 * a)
+	return diff.Scale(t).Add(cauchy)
+}
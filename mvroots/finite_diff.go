@@ -0,0 +1,109 @@
+package mvroots
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A FiniteDiffAdapter turns a vector-valued function F
+// into a Func by approximating its Jacobian with
+// central differences.
+type FiniteDiffAdapter struct {
+	// N is the dimensionality of F.
+	N int
+	F func(x linalg.Vector) linalg.Vector
+
+	// Step is the relative step size for the finite
+	// differences.
+	// If it is 0, a default of roughly the cube root of
+	// the machine epsilon is used.
+	Step float64
+}
+
+// Dim returns f.N.
+func (f FiniteDiffAdapter) Dim() int {
+	return f.N
+}
+
+// Eval evaluates F.
+func (f FiniteDiffAdapter) Eval(vec linalg.Vector) linalg.Vector {
+	if len(vec) != f.N {
+		panic("wrong dimensionality")
+	}
+	return f.F(vec)
+}
+
+// Jacobian approximates the Jacobian of F.
+func (f FiniteDiffAdapter) Jacobian(vec linalg.Vector) *linalg.Matrix {
+	if len(vec) != f.N {
+		panic("wrong dimensionality")
+	}
+	return FiniteDiffJacobian(f.F, vec, f.Step)
+}
+
+// FiniteDiffJacobian approximates the Jacobian of f at x
+// using central differences.
+//
+// The i-th input is perturbed by step*max(1, |x[i]|).
+// If step is 0, a default of roughly the cube root of
+// the machine epsilon is used, which balances truncation
+// and rounding error.
+func FiniteDiffJacobian(f func(x linalg.Vector) linalg.Vector, x linalg.Vector,
+	step float64) *linalg.Matrix {
+	if step == 0 {
+		step = math.Cbrt(math.Nextafter(1, 2) - 1)
+	}
+	if len(x) == 0 {
+		return linalg.NewMatrix(len(f(x)), 0)
+	}
+	var res *linalg.Matrix
+	for col := range x {
+		h := step * math.Max(1, math.Abs(x[col]))
+
+		// Make h exactly representable relative to x[col].
+		forward := x.Copy()
+		forward[col] += h
+		backward := x.Copy()
+		backward[col] -= h
+		h2 := forward[col] - backward[col]
+
+		diff := f(forward).Add(f(backward).Scale(-1))
+		if res == nil {
+			res = linalg.NewMatrix(len(diff), len(x))
+		}
+		for row, d := range diff {
+			res.Set(row, col, d/h2)
+		}
+	}
+	return res
+}
+
+// CheckJacobian compares the Jacobian of f at x to a
+// finite difference approximation, to catch mistakes
+// in hand-written Jacobians.
+//
+// It returns the largest discrepancy between the two,
+// relative to max(1, |entry|), along with the row and
+// column where it occurs.
+// The step argument is passed to FiniteDiffJacobian.
+func CheckJacobian(f Func, x linalg.Vector, step float64) (maxErr float64, row, col int) {
+	actual := f.Jacobian(x)
+	expected := FiniteDiffJacobian(f.Eval, x, step)
+	if actual.Rows != expected.Rows || actual.Cols != expected.Cols {
+		panic("dimension mismatch")
+	}
+	for i := 0; i < actual.Rows; i++ {
+		for j := 0; j < actual.Cols; j++ {
+			a, e := actual.Get(i, j), expected.Get(i, j)
+			scale := math.Max(1, math.Max(math.Abs(a), math.Abs(e)))
+			if diff := math.Abs(a-e) / scale; diff > maxErr || math.IsNaN(diff) {
+				maxErr, row, col = diff, i, j
+				if math.IsNaN(diff) {
+					return
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,61 @@
+package mvroots
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestFiniteDiffAdapter(t *testing.T) {
+	for _, step := range []float64{0, 1e-4} {
+		f := FiniteDiffAdapter{N: 2, F: atanFunc{}.Eval, Step: step}
+		for i := 0; i < 5; i++ {
+			x := linalg.RandVector(2).Scale(3)
+			actual := f.Jacobian(x)
+			expected := atanFunc{}.Jacobian(x)
+			for j, a := range actual.Data {
+				if math.Abs(a-expected.Data[j]) > 1e-7 {
+					t.Error("bad Jacobian at", x, "with step", step, ":", actual.Data)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestFiniteDiffJacobianEmpty(t *testing.T) {
+	f := func(x linalg.Vector) linalg.Vector {
+		return linalg.Vector{1, 2, 3}
+	}
+	jac := FiniteDiffJacobian(f, linalg.Vector{}, 0)
+	if jac == nil || jac.Rows != 3 || jac.Cols != 0 {
+		t.Error("expected 3x0 matrix but got", jac)
+	}
+}
+
+func TestCheckJacobian(t *testing.T) {
+	x := linalg.Vector{0.5, -1.5}
+	if maxErr, _, _ := CheckJacobian(circleLineFunc{}, x, 0); maxErr > 1e-8 {
+		t.Error("unexpected error", maxErr)
+	}
+	if maxErr, _, _ := CheckJacobian(atanFunc{}, x, 0); maxErr > 1e-8 {
+		t.Error("unexpected error", maxErr)
+	}
+	maxErr, row, col := CheckJacobian(wrongJacobianFunc{}, x, 0)
+	if maxErr < 0.1 || row != 1 || col != 0 {
+		t.Error("unexpected result", maxErr, row, col)
+	}
+}
+
+// wrongJacobianFunc is circleLineFunc with a mistake
+// in the second row of the Jacobian.
+type wrongJacobianFunc struct {
+	circleLineFunc
+}
+
+func (_ wrongJacobianFunc) Jacobian(v linalg.Vector) *linalg.Matrix {
+	res := circleLineFunc{}.Jacobian(v)
+	res.Set(1, 0, -1)
+	return res
+}
@@ -0,0 +1,98 @@
+package mvroots
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestLineSearchContext(t *testing.T) {
+	testGlobalizedMethod(t, LineSearchContext)
+}
+
+func TestDoglegContext(t *testing.T) {
+	testGlobalizedMethod(t, DoglegContext)
+}
+
+func TestDoglegRadius(t *testing.T) {
+	iter := NewDoglegIterator(atanFunc{}, linalg.Vector{10, -10}, 0.5)
+	for i := 0; i < 5; i++ {
+		radius := iter.Radius()
+		if dist := iter.Step(); dist > radius*(1+1e-12) {
+			t.Error("step", dist, "exceeded radius", radius)
+		}
+	}
+}
+
+func TestBroydenContext(t *testing.T) {
+	f := &countingFunc{Func: circleLineFunc{}}
+	root, err := BroydenContext(context.Background(), f, linalg.Vector{1, 2}, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := linalg.Vector{math.Sqrt2, math.Sqrt2}
+	if math.Abs(root[0]-expected[0]) > 1e-10 || math.Abs(root[1]-expected[1]) > 1e-10 {
+		t.Error("expected", expected, "but got", root)
+	}
+	if f.jacobians != 1 {
+		t.Error("expected 1 Jacobian evaluation but got", f.jacobians)
+	}
+}
+
+func testGlobalizedMethod(t *testing.T, method func(context.Context, Func,
+	linalg.Vector, float64) (linalg.Vector, error)) {
+	// Plain Newton's method diverges from this start.
+	start := linalg.Vector{3, -5}
+	if guess, _ := NewtonContext(context.Background(), atanFunc{}, start, 1e-12); guess.Mag() < 1 {
+		t.Fatal("test function should defeat Newton's method")
+	}
+	root, err := method(context.Background(), atanFunc{}, start, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Mag() > 1e-10 {
+		t.Error("expected root at origin but got", root)
+	}
+
+	root, err = method(context.Background(), circleLineFunc{}, linalg.Vector{10, 30}, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(math.Abs(root[0])-math.Sqrt2) > 1e-10 || math.Abs(root[0]-root[1]) > 1e-10 {
+		t.Error("bad root", root)
+	}
+}
+
+// atanFunc has a root at the origin, but Newton's
+// method diverges when started far from it.
+type atanFunc struct{}
+
+func (_ atanFunc) Dim() int {
+	return 2
+}
+
+func (_ atanFunc) Eval(v linalg.Vector) linalg.Vector {
+	return linalg.Vector{math.Atan(v[0]), math.Atan(v[0] + v[1])}
+}
+
+func (_ atanFunc) Jacobian(v linalg.Vector) *linalg.Matrix {
+	d1 := 1 / (1 + v[0]*v[0])
+	d2 := 1 / (1 + (v[0]+v[1])*(v[0]+v[1]))
+	return &linalg.Matrix{
+		Rows: 2,
+		Cols: 2,
+		Data: []float64{d1, 0, d2, d2},
+	}
+}
+
+type countingFunc struct {
+	Func
+	jacobians int
+}
+
+func (c *countingFunc) Jacobian(v linalg.Vector) *linalg.Matrix {
+	c.jacobians++
+	return c.Func.Jacobian(v)
+}
@@ -0,0 +1,87 @@
+package mvroots
+
+import (
+	"context"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+const (
+	// armijoFactor is the fraction of the predicted
+	// decrease that a line search step must achieve.
+	armijoFactor = 1e-4
+
+	maxBacktracks = 60
+)
+
+// LineSearchIterator is like Iterator, but it damps
+// each Newton step with a backtracking line search on
+// the squared norm of the function, ||F||^2.
+//
+// Each step is halved until it sufficiently decreases
+// ||F||^2, which keeps the iteration from diverging
+// from poor starting points.
+// When the Jacobian is singular, the iterator falls
+// back to the steepest descent direction of ||F||^2.
+type LineSearchIterator struct {
+	function Func
+	guess    linalg.Vector
+}
+
+// NewLineSearchIterator creates a LineSearchIterator
+// with a given input vector at which to start the
+// search.
+func NewLineSearchIterator(f Func, start linalg.Vector) *LineSearchIterator {
+	return &LineSearchIterator{function: f, guess: start}
+}
+
+// Step performs one root-finding iteration.
+// It returns the Euclidean distance between
+// the previous guess and the current guess.
+//
+// If no step along the search direction decreases
+// ||F||^2, the guess is left unchanged and 0 is
+// returned.
+func (l *LineSearchIterator) Step() float64 {
+	value := l.function.Eval(l.guess)
+	if isZeroVector(value) {
+		return 0
+	}
+	normSq := value.Dot(value)
+
+	jacobian := l.function.Jacobian(l.guess)
+	direction, ok := newtonDirection(jacobian, value)
+	if !ok {
+		direction = mulVec(jacobian.Transpose(), value).Scale(-1)
+	}
+	slope := 2 * value.Dot(mulVec(jacobian, direction))
+	if !(slope < 0) {
+		return 0
+	}
+
+	scale := 1.0
+	for i := 0; i < maxBacktracks; i++ {
+		step := direction.Copy().Scale(scale)
+		candidate := step.Copy().Add(l.guess)
+		newValue := l.function.Eval(candidate)
+		if isFiniteVector(newValue) &&
+			newValue.Dot(newValue) <= normSq+armijoFactor*scale*slope {
+			l.guess = candidate
+			return step.Mag()
+		}
+		scale /= 2
+	}
+	return 0
+}
+
+// Guess returns the current approximate root.
+func (l *LineSearchIterator) Guess() linalg.Vector {
+	return l.guess
+}
+
+// LineSearchContext is like NewtonContext, but it uses
+// a LineSearchIterator.
+func LineSearchContext(ctx context.Context, f Func, start linalg.Vector,
+	prec float64) (linalg.Vector, error) {
+	return searchContext(ctx, f, NewLineSearchIterator(f, start.Copy()), start, prec)
+}
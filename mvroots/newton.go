@@ -1,6 +1,7 @@
 package mvroots
 
 import (
+	"context"
 	"math"
 
 	"github.com/unixpickle/num-analysis/linalg"
@@ -58,3 +59,21 @@ func (i *Iterator) Step() float64 {
 func (i *Iterator) Guess() linalg.Vector {
 	return i.guess
 }
+
+// NewtonContext uses Newton's method to search for a
+// root of f, starting at the given input vector.
+//
+// The search stops once a step moves the guess by less
+// than prec (as measured by Euclidean distance), or
+// once it reaches a root.
+// If the iteration stalls away from a root (e.g. at
+// a singular Jacobian), diverges, or stops improving
+// for 50 steps (e.g. because it is cycling), the best
+// guess is returned along with ErrNoConvergence.
+// If ctx is done first, the guess with the smallest
+// function value (as measured by Euclidean norm) is
+// returned along with ctx.Err().
+func NewtonContext(ctx context.Context, f Func, start linalg.Vector,
+	prec float64) (linalg.Vector, error) {
+	return searchContext(ctx, f, NewIterator(f, start.Copy()), start, prec)
+}
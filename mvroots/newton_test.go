@@ -0,0 +1,139 @@
+package mvroots
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestNewtonContext(t *testing.T) {
+	root, err := NewtonContext(context.Background(), circleLineFunc{}, linalg.Vector{1, 2}, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := linalg.Vector{math.Sqrt2, math.Sqrt2}
+	if math.Abs(root[0]-expected[0]) > 1e-10 || math.Abs(root[1]-expected[1]) > 1e-10 {
+		t.Error("expected", expected, "but got", root)
+	}
+}
+
+func TestNewtonContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Newton's method diverges for the cube root, so
+	// the starting point stays the best guess.
+	start := linalg.Vector{1}
+	guess, err := NewtonContext(ctx, cubeRootFunc{}, start, 1e-12)
+	if err != context.Canceled {
+		t.Error("expected Canceled but got", err)
+	}
+	if len(guess) != 1 || guess[0] != 1 {
+		t.Error("expected", start, "but got", guess)
+	}
+}
+
+func TestNewtonContextNoConvergence(t *testing.T) {
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewtonContext(context.Background(), cubicFunc{}, linalg.Vector{0}, 1e-12)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrNoConvergence {
+			t.Error("expected ErrNoConvergence but got", err)
+		}
+	case <-time.After(time.Second * 10):
+		t.Fatal("cycling iteration never stopped")
+	}
+
+	// The Jacobian is singular at the start.
+	guess, err := NewtonContext(context.Background(), shiftedSquareFunc{},
+		linalg.Vector{0}, 1e-12)
+	if err != ErrNoConvergence {
+		t.Error("expected ErrNoConvergence but got", err)
+	}
+	if len(guess) != 1 || guess[0] != 0 {
+		t.Error("expected best guess [0] but got", guess)
+	}
+
+	// Broyden's method wanders without finding a root.
+	_, err = BroydenContext(context.Background(), shiftedSquareFunc{}, linalg.Vector{1}, 1e-12)
+	if err != ErrNoConvergence {
+		t.Error("expected ErrNoConvergence but got", err)
+	}
+}
+
+// circleLineFunc has a root where the circle of
+// radius 2 meets the line y=x.
+type circleLineFunc struct{}
+
+func (_ circleLineFunc) Dim() int {
+	return 2
+}
+
+func (_ circleLineFunc) Eval(v linalg.Vector) linalg.Vector {
+	return linalg.Vector{v[0]*v[0] + v[1]*v[1] - 4, v[0] - v[1]}
+}
+
+func (_ circleLineFunc) Jacobian(v linalg.Vector) *linalg.Matrix {
+	return &linalg.Matrix{
+		Rows: 2,
+		Cols: 2,
+		Data: []float64{2 * v[0], 2 * v[1], 1, -1},
+	}
+}
+
+type cubeRootFunc struct{}
+
+func (_ cubeRootFunc) Dim() int {
+	return 1
+}
+
+func (_ cubeRootFunc) Eval(v linalg.Vector) linalg.Vector {
+	return linalg.Vector{math.Cbrt(v[0])}
+}
+
+func (_ cubeRootFunc) Jacobian(v linalg.Vector) *linalg.Matrix {
+	return &linalg.Matrix{
+		Rows: 1,
+		Cols: 1,
+		Data: []float64{1 / (3 * math.Pow(math.Abs(v[0]), 2.0/3))},
+	}
+}
+
+// cubicFunc computes x^3 - 2x + 2, for which Newton's
+// method cycles between 0 and 1.
+type cubicFunc struct{}
+
+func (_ cubicFunc) Dim() int {
+	return 1
+}
+
+func (_ cubicFunc) Eval(v linalg.Vector) linalg.Vector {
+	return linalg.Vector{v[0]*v[0]*v[0] - 2*v[0] + 2}
+}
+
+func (_ cubicFunc) Jacobian(v linalg.Vector) *linalg.Matrix {
+	return &linalg.Matrix{Rows: 1, Cols: 1, Data: []float64{3*v[0]*v[0] - 2}}
+}
+
+// shiftedSquareFunc computes x^2 + 1, which has no
+// real roots.
+type shiftedSquareFunc struct{}
+
+func (_ shiftedSquareFunc) Dim() int {
+	return 1
+}
+
+func (_ shiftedSquareFunc) Eval(v linalg.Vector) linalg.Vector {
+	return linalg.Vector{v[0]*v[0] + 1}
+}
+
+func (_ shiftedSquareFunc) Jacobian(v linalg.Vector) *linalg.Matrix {
+	return &linalg.Matrix{Rows: 1, Cols: 1, Data: []float64{2 * v[0]}}
+}
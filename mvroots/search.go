@@ -0,0 +1,109 @@
+package mvroots
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/ludecomp"
+)
+
+// ErrNoConvergence is returned when a root-finding
+// iteration stops without finding a root.
+var ErrNoConvergence = errors.New("root-finding iteration did not converge")
+
+// searchStallSteps is the number of steps without
+// improvement after which a search gives up.
+const searchStallSteps = 50
+
+// stepper is implemented by every root-finding
+// iterator in this package.
+type stepper interface {
+	Step() float64
+	Guess() linalg.Vector
+}
+
+// searchContext runs s until a step moves the guess by
+// less than prec, keeping track of the best guess in
+// case the iteration fails or ctx is done.
+//
+// The search fails with ErrNoConvergence if the
+// iterator stalls more than a Newton step of prec away
+// from a root, if it diverges, or if the best guess
+// does not improve for searchStallSteps steps in a row
+// (e.g. because the iteration is cycling).
+func searchContext(ctx context.Context, f Func, s stepper, start linalg.Vector,
+	prec float64) (linalg.Vector, error) {
+	best := start.Copy()
+	bestNorm := f.Eval(start).Mag()
+	var stallSteps int
+	for {
+		dist := s.Step()
+		if math.IsNaN(dist) {
+			return best, ErrNoConvergence
+		} else if dist == 0 {
+			if nearRoot(f, s.Guess(), prec) {
+				return s.Guess(), nil
+			}
+			return best, ErrNoConvergence
+		} else if dist < prec {
+			return s.Guess(), nil
+		}
+		if norm := f.Eval(s.Guess()).Mag(); norm < bestNorm {
+			bestNorm = norm
+			best = s.Guess().Copy()
+			stallSteps = 0
+		} else if stallSteps++; stallSteps >= searchStallSteps {
+			return best, ErrNoConvergence
+		}
+		select {
+		case <-ctx.Done():
+			return best, ctx.Err()
+		default:
+		}
+	}
+}
+
+// nearRoot checks if x is a root of f, or if a Newton
+// step from x would move it by less than prec.
+func nearRoot(f Func, x linalg.Vector, prec float64) bool {
+	value := f.Eval(x)
+	if isZeroVector(value) {
+		return true
+	}
+	step, ok := newtonDirection(f.Jacobian(x), value)
+	return ok && step.Mag() < prec
+}
+
+// newtonDirection solves jacobian*x = -value.
+// It returns false if the Jacobian is singular.
+func newtonDirection(jacobian *linalg.Matrix, value linalg.Vector) (linalg.Vector, bool) {
+	lu := ludecomp.Decompose(jacobian)
+	if lu.PivotScale() < math.Nextafter(1, 2)-1 {
+		return nil, false
+	}
+	return lu.Solve(value).Scale(-1), true
+}
+
+func mulVec(m *linalg.Matrix, v linalg.Vector) linalg.Vector {
+	return linalg.Vector(m.Mul(linalg.NewMatrixColumn(v)).Data)
+}
+
+func isZeroVector(v linalg.Vector) bool {
+	for _, x := range v {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isFiniteVector(v linalg.Vector) bool {
+	for _, x := range v {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package optimization
+
+import (
+	"github.com/unixpickle/num-analysis/autodiff"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A NumFunc is a scalar-valued function written in
+// terms of autodiff.Num values.
+type NumFunc func(x []autodiff.Num) autodiff.Num
+
+// An AutoAdapter turns a NumFunc F into a GradFunc,
+// computing the gradient with automatic
+// differentiation.
+//
+// Each input to F has a gradient of size N, so any
+// constants in F should be created with
+// autodiff.NewNum(c, N).
+type AutoAdapter struct {
+	// N is the number of inputs to F.
+	N int
+	F NumFunc
+}
+
+// Dim returns a.N.
+func (a AutoAdapter) Dim() int {
+	return a.N
+}
+
+// Eval evaluates F.
+func (a AutoAdapter) Eval(vec linalg.Vector) float64 {
+	return a.apply(vec).Value
+}
+
+// Gradient evaluates the gradient of F.
+func (a AutoAdapter) Gradient(vec linalg.Vector) linalg.Vector {
+	return linalg.Vector(a.apply(vec).Gradient)
+}
+
+func (a AutoAdapter) apply(vec linalg.Vector) autodiff.Num {
+	if len(vec) != a.N {
+		panic("wrong dimensionality")
+	}
+	in := make([]autodiff.Num, a.N)
+	for i, x := range vec {
+		in[i] = autodiff.NewNumVar(x, a.N, i)
+	}
+	return a.F(in)
+}
@@ -0,0 +1,34 @@
+package optimization
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/autodiff"
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestAutoAdapter(t *testing.T) {
+	// (x-1)^2 + 2(y+3)^2
+	f := AutoAdapter{
+		N: 2,
+		F: func(x []autodiff.Num) autodiff.Num {
+			a := x[0].Sub(autodiff.NewNum(1, 2))
+			b := x[1].Add(autodiff.NewNum(3, 2))
+			return a.Mul(a).Add(b.Mul(b).Add(b.Mul(b)))
+		},
+	}
+	x := linalg.Vector{2, 1}
+	if val := f.Eval(x); math.Abs(val-33) > 1e-12 {
+		t.Error("expected 33 but got", val)
+	}
+	grad := f.Gradient(x)
+	if math.Abs(grad[0]-2) > 1e-12 || math.Abs(grad[1]-16) > 1e-12 {
+		t.Error("expected [2 16] but got", grad)
+	}
+
+	min := GradientDescent(f, 1e-8)
+	if math.Abs(min[0]-1) > 1e-5 || math.Abs(min[1]+3) > 1e-5 {
+		t.Error("expected [1 -3] but got", min)
+	}
+}
@@ -1,6 +1,7 @@
 package optimization
 
 import (
+	"context"
 	"math"
 
 	"github.com/unixpickle/num-analysis/linalg"
@@ -13,8 +14,25 @@ import (
 // vector by less than prec (as measured by
 // Euclidean distance), then the algorithm
 // terminates.
+//
+// The guess with the lowest objective value is
+// returned, which is not necessarily the last iterate,
+// since the final step may fail to improve on it.
 func GradientDescent(f GradFunc, prec float64) linalg.Vector {
+	res, _ := GradientDescentContext(context.Background(), f, prec)
+	return res
+}
+
+// GradientDescentContext is like GradientDescent,
+// but it stops early if ctx is done.
+//
+// If ctx is done before the descent converges, the
+// guess with the lowest objective value so far is
+// returned along with ctx.Err().
+func GradientDescentContext(ctx context.Context, f GradFunc,
+	prec float64) (linalg.Vector, error) {
 	guess := make(linalg.Vector, f.Dim())
+	best := guess.Copy()
 	lastValue := f.Eval(guess)
 	for {
 		gradient := f.Gradient(guess)
@@ -35,13 +53,20 @@ func GradientDescent(f GradFunc, prec float64) linalg.Vector {
 			break
 		}
 		lastValue = value
+		best = guess.Copy()
 
 		dist := math.Sqrt(gradient.Dot(gradient))
 		if dist < prec {
 			break
 		}
+
+		select {
+		case <-ctx.Done():
+			return best, ctx.Err()
+		default:
+		}
 	}
-	return guess
+	return best, nil
 }
 
 type stepSizeFunc struct {
@@ -1,6 +1,7 @@
 package optimization
 
 import (
+	"context"
 	"testing"
 
 	"github.com/unixpickle/num-analysis/linalg"
@@ -69,3 +70,70 @@ func TestGradientDescentProjectionMany(t *testing.T) {
 		t.Error("expected", expectedProduct, "but got", actualProduct)
 	}
 }
+
+func TestGradientDescentContext(t *testing.T) {
+	matrix := &linalg.Matrix{
+		Rows: 4,
+		Cols: 4,
+		Data: []float64{
+			1, 2, 3, 4,
+			5, 6, 7, 8,
+			3, 4, 1, 2,
+			8, 9, 10, 12,
+		},
+	}
+	product := linalg.Vector{400, 300, 20, -30.5}
+	sys := NewLinSysFunc(matrix, product)
+
+	actual, err := GradientDescentContext(context.Background(), sys, 1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := linalg.Vector{-623, 515.5, 338, -255.5}
+	diff := actual.Copy().Add(expected.Scale(-1))
+	if diff.Dot(diff) > 1e-10 {
+		t.Error("expected", expected, "but got", actual)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	actual, err = GradientDescentContext(ctx, sys, 1e-12)
+	if err != context.Canceled {
+		t.Error("expected Canceled but got", err)
+	}
+	if sys.Eval(actual) >= sys.Eval(make(linalg.Vector, 4)) {
+		t.Error("partial result did not improve on the start")
+	}
+}
+
+func TestGradientDescentBestIterate(t *testing.T) {
+	// The minimum is at the start, so the first step
+	// can only make the objective worse.
+	f := kinkFunc{}
+	actual := GradientDescent(f, 1e-12)
+	if f.Eval(actual) != 0 {
+		t.Error("expected the start (with value 0) but got", actual)
+	}
+}
+
+// kinkFunc is a convex function with its minimum at the
+// origin, where it reports a non-zero subgradient.
+type kinkFunc struct{}
+
+func (_ kinkFunc) Dim() int {
+	return 1
+}
+
+func (_ kinkFunc) Eval(v linalg.Vector) float64 {
+	if v[0] > 0 {
+		return 3 * v[0]
+	}
+	return -v[0]
+}
+
+func (_ kinkFunc) Gradient(v linalg.Vector) linalg.Vector {
+	if v[0] > 0 {
+		return linalg.Vector{3}
+	}
+	return linalg.Vector{-1}
+}
@@ -0,0 +1,236 @@
+// Package polynomial implements arithmetic on
+// polynomials with real coefficients.
+package polynomial
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/mvroots"
+	"github.com/unixpickle/num-analysis/realroots"
+	"github.com/unixpickle/num-analysis/regression"
+)
+
+// A Polynomial represents a polynomial with real
+// coefficients.
+//
+// For a Polynomial p, the x^0 coefficient is p[0], the
+// x^1 coefficient is p[1], etc.
+// Leading zero coefficients are allowed, and the empty
+// Polynomial is zero.
+type Polynomial []float64
+
+// Degree returns the degree of p, ignoring leading
+// zero coefficients.
+// The zero polynomial has degree -1.
+func (p Polynomial) Degree() int {
+	return len(p.Trim()) - 1
+}
+
+// Trim returns p without its leading zero
+// coefficients.
+// The result shares memory with p.
+func (p Polynomial) Trim() Polynomial {
+	for len(p) > 0 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+// Copy returns a copy of p.
+func (p Polynomial) Copy() Polynomial {
+	return append(Polynomial{}, p...)
+}
+
+// Add returns p + q.
+func (p Polynomial) Add(q Polynomial) Polynomial {
+	if len(q) > len(p) {
+		p, q = q, p
+	}
+	res := p.Copy()
+	for i, x := range q {
+		res[i] += x
+	}
+	return res.Trim()
+}
+
+// Sub returns p - q.
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	return p.Add(q.Scale(-1))
+}
+
+// Scale returns c*p.
+func (p Polynomial) Scale(c float64) Polynomial {
+	res := make(Polynomial, len(p))
+	for i, x := range p {
+		res[i] = x * c
+	}
+	return res.Trim()
+}
+
+// Mul returns p*q.
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	p, q = p.Trim(), q.Trim()
+	if len(p) == 0 || len(q) == 0 {
+		return Polynomial{}
+	}
+	res := make(Polynomial, len(p)+len(q)-1)
+	for i, x := range p {
+		for j, y := range q {
+			res[i+j] += x * y
+		}
+	}
+	return res
+}
+
+// DivMod divides p by d, returning a quotient q and a
+// remainder r such that p = q*d + r and the degree of
+// r is less than the degree of d.
+//
+// This panics if d is zero.
+func (p Polynomial) DivMod(d Polynomial) (q, r Polynomial) {
+	d = d.Trim()
+	if len(d) == 0 {
+		panic("division by zero polynomial")
+	}
+	r = p.Trim().Copy()
+	if len(r) < len(d) {
+		return Polynomial{}, r
+	}
+	q = make(Polynomial, len(r)-len(d)+1)
+	leading := d[len(d)-1]
+	for i := len(q) - 1; i >= 0; i-- {
+		coeff := r[i+len(d)-1] / leading
+		q[i] = coeff
+		for j, x := range d {
+			r[i+j] -= coeff * x
+		}
+		r[i+len(d)-1] = 0
+	}
+	return q.Trim(), r.Trim()
+}
+
+// GCD computes the monic greatest common divisor of p
+// and q using the Euclidean algorithm.
+//
+// Since rounding errors make exact remainders rare,
+// a remainder is treated as zero once its largest
+// coefficient is at most tol times the largest
+// coefficient of the polynomials being divided.
+// If both p and q are zero, the result is zero.
+func (p Polynomial) GCD(q Polynomial, tol float64) Polynomial {
+	a, b := p.Trim(), q.Trim()
+	scale := math.Max(a.maxAbs(), b.maxAbs())
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	for len(b) > 0 && b.maxAbs() > tol*scale {
+		_, r := a.DivMod(b)
+		a, b = b, r
+	}
+	if len(a) == 0 {
+		return Polynomial{}
+	}
+	return a.Scale(1 / a[len(a)-1])
+}
+
+// Compose returns the polynomial p(q(x)).
+func (p Polynomial) Compose(q Polynomial) Polynomial {
+	var res Polynomial
+	for i := len(p) - 1; i >= 0; i-- {
+		res = res.Mul(q).Add(Polynomial{p[i]})
+	}
+	return res.Trim()
+}
+
+// Derivative returns the derivative of p.
+func (p Polynomial) Derivative() Polynomial {
+	if len(p) < 2 {
+		return Polynomial{}
+	}
+	res := make(Polynomial, len(p)-1)
+	for i := range res {
+		res[i] = p[i+1] * float64(i+1)
+	}
+	return res.Trim()
+}
+
+// Antiderivative returns the antiderivative of p
+// whose constant term is zero.
+func (p Polynomial) Antiderivative() Polynomial {
+	p = p.Trim()
+	if len(p) == 0 {
+		return Polynomial{}
+	}
+	res := make(Polynomial, len(p)+1)
+	for i, x := range p {
+		res[i+1] = x / float64(i+1)
+	}
+	return res
+}
+
+// Integral computes the definite integral of p from a
+// to b.
+func (p Polynomial) Integral(a, b float64) float64 {
+	anti := p.Antiderivative()
+	return anti.Eval(b) - anti.Eval(a)
+}
+
+// Eval evaluates p at x using Horner's method.
+func (p Polynomial) Eval(x float64) float64 {
+	var res float64
+	for i := len(p) - 1; i >= 0; i-- {
+		res = res*x + p[i]
+	}
+	return res
+}
+
+// EvalError evaluates p at x using Horner's method,
+// returning the result along with a bound on the
+// rounding error in the result.
+//
+// The bound comes from a running error analysis of
+// Horner's method, so it is usually much tighter than
+// an a priori bound.
+// It does not account for errors in the coefficients
+// themselves.
+func (p Polynomial) EvalError(x float64) (value, bound float64) {
+	if len(p) == 0 {
+		return 0, 0
+	}
+	unitRoundoff := (math.Nextafter(1, 2) - 1) / 2
+	value = p[len(p)-1]
+	mu := math.Abs(value) / 2
+	for i := len(p) - 2; i >= 0; i-- {
+		value = value*x + p[i]
+		mu = math.Abs(x)*mu + math.Abs(value)
+	}
+	bound = unitRoundoff * (2*mu - math.Abs(value))
+	return
+}
+
+// RealRoots converts p to a realroots.Polynomial.
+func (p Polynomial) RealRoots() realroots.Polynomial {
+	return realroots.Polynomial(p.Copy())
+}
+
+// Regression converts p to a regression.Polynomial.
+func (p Polynomial) Regression() regression.Polynomial {
+	return regression.Polynomial(p.Copy())
+}
+
+// Complex converts p to an mvroots.Polynomial.
+func (p Polynomial) Complex() mvroots.Polynomial {
+	res := make(mvroots.Polynomial, len(p))
+	for i, x := range p {
+		res[i] = complex(x, 0)
+	}
+	return res
+}
+
+func (p Polynomial) maxAbs() float64 {
+	var res float64
+	for _, x := range p {
+		res = math.Max(res, math.Abs(x))
+	}
+	return res
+}
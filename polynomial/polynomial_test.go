@@ -0,0 +1,146 @@
+package polynomial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestArithmetic(t *testing.T) {
+	p := Polynomial{1, 2, 3}
+	q := Polynomial{-1, 0, -3, 4}
+
+	checks := []struct {
+		name     string
+		actual   Polynomial
+		expected Polynomial
+	}{
+		{"sum", p.Add(q), Polynomial{0, 2, 0, 4}},
+		{"difference", p.Sub(Polynomial{0, 0, 3}), Polynomial{1, 2}},
+		{"product", p.Mul(q), Polynomial{-1, -2, -6, -2, -1, 12}},
+		{"scale", p.Scale(2), Polynomial{2, 4, 6}},
+		{"composition", p.Compose(Polynomial{1, 1}), Polynomial{6, 8, 3}},
+		{"derivative", q.Derivative(), Polynomial{0, -6, 12}},
+		{"antiderivative", p.Antiderivative(), Polynomial{0, 1, 1, 1}},
+		{"zero product", p.Mul(Polynomial{0, 0}), Polynomial{}},
+	}
+	for _, c := range checks {
+		if !polysClose(c.actual, c.expected, 1e-12) {
+			t.Error("bad", c.name, "- expected", c.expected, "but got", c.actual)
+		}
+	}
+
+	if p.Degree() != 2 || (Polynomial{1, 0, 0}).Degree() != 0 || (Polynomial{0}).Degree() != -1 {
+		t.Error("bad degrees")
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		p := randomPolynomial(8)
+		d := randomPolynomial(3)
+		d[3] = 1
+		q, r := p.DivMod(d)
+		if r.Degree() >= d.Degree() {
+			t.Fatal("remainder degree is too high:", r)
+		}
+		if !polysClose(q.Mul(d).Add(r), p, 1e-8) {
+			t.Fatal("q*d + r != p")
+		}
+	}
+
+	q, r := Polynomial{1, 2}.DivMod(Polynomial{0, 0, 1})
+	if len(q) != 0 || !polysClose(r, Polynomial{1, 2}, 0) {
+		t.Error("unexpected division result:", q, r)
+	}
+}
+
+func TestGCD(t *testing.T) {
+	// (x-1)(x+2) and (x-1)(x-3)(x+4).
+	a := Polynomial{-1, 1}.Mul(Polynomial{2, 1})
+	b := Polynomial{-1, 1}.Mul(Polynomial{-3, 1}).Mul(Polynomial{4, 1})
+	gcd := a.GCD(b, 1e-10)
+	if !polysClose(gcd, Polynomial{-1, 1}, 1e-10) {
+		t.Error("expected x-1 but got", gcd)
+	}
+
+	gcd = a.Scale(3).GCD(a.Mul(Polynomial{5, 2}), 1e-10)
+	if !polysClose(gcd, a, 1e-10) {
+		t.Error("expected", a, "but got", gcd)
+	}
+
+	gcd = Polynomial{1, 1}.GCD(Polynomial{1, -1}, 1e-10)
+	if !polysClose(gcd, Polynomial{1}, 1e-10) {
+		t.Error("expected 1 but got", gcd)
+	}
+}
+
+func TestIntegral(t *testing.T) {
+	p := Polynomial{1, -2, 3}
+	if actual := p.Integral(-1, 2); math.Abs(actual-9) > 1e-12 {
+		t.Error("expected 9 but got", actual)
+	}
+}
+
+func TestEvalError(t *testing.T) {
+	// (x-1)^8 suffers heavy cancellation near x = 1.
+	p := Polynomial{1}
+	for i := 0; i < 8; i++ {
+		p = p.Mul(Polynomial{-1, 1})
+	}
+	for _, x := range []float64{0.99, 1.001, 1.01, 2, -3} {
+		value, bound := p.EvalError(x)
+		if value != p.Eval(x) {
+			t.Error("EvalError and Eval disagree")
+		}
+		actual := math.Pow(x-1, 8)
+		if math.Abs(value-actual) > bound {
+			t.Error("error bound", bound, "is too small at", x, "- got", value, "expected", actual)
+		}
+		if bound > 1e-12*math.Max(1, math.Pow(math.Abs(x)+1, 8)) {
+			t.Error("error bound", bound, "is too large at", x)
+		}
+	}
+}
+
+func TestConversions(t *testing.T) {
+	p := Polynomial{1, -3, 2}
+	x := 0.7
+	expected := p.Eval(x)
+	if actual := p.RealRoots().Eval(x); math.Abs(actual-expected) > 1e-12 {
+		t.Error("bad realroots conversion")
+	}
+	if actual := p.Regression().Eval(x); math.Abs(actual-expected) > 1e-12 {
+		t.Error("bad regression conversion")
+	}
+	if actual := p.Complex().Eval(complex(x, 0)); math.Abs(real(actual)-expected) > 1e-12 ||
+		imag(actual) != 0 {
+		t.Error("bad mvroots conversion")
+	}
+
+	derivative := p.RealRoots().Derivative()
+	if !polysClose(Polynomial(derivative), p.Derivative(), 0) {
+		t.Error("expected derivative", p.Derivative(), "but got", derivative)
+	}
+}
+
+func randomPolynomial(deg int) Polynomial {
+	res := make(Polynomial, deg+1)
+	for i := range res {
+		res[i] = rand.Float64()*2 - 1
+	}
+	return res
+}
+
+func polysClose(p1, p2 Polynomial, tol float64) bool {
+	p1, p2 = p1.Trim(), p2.Trim()
+	if len(p1) != len(p2) {
+		return false
+	}
+	for i, x := range p1 {
+		if math.Abs(x-p2[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+package polynomial
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+const aberthMaxIters = 500
+
+// ErrNoConvergence is returned when a root finder
+// fails to converge.
+var ErrNoConvergence = errors.New("root finder did not converge")
+
+// A Root is a complex root of a polynomial.
+type Root struct {
+	Value        complex128
+	Multiplicity int
+
+	// ErrorBound is the radius of a disc around Value
+	// which contains Multiplicity roots of the
+	// polynomial, counted with multiplicity.
+	ErrorBound float64
+}
+
+// Roots finds all of the complex roots of p using the
+// Aberth-Ehrlich method, which refines approximations
+// to every root simultaneously instead of deflating
+// one root at a time.
+//
+// Approximations which cannot be told apart with the
+// error bounds are merged into a single Root with a
+// multiplicity, so a multiple root is reported once.
+// The roots are sorted by real part, then by
+// imaginary part.
+//
+// This panics if p is the zero polynomial.
+func (p Polynomial) Roots() ([]Root, error) {
+	p = p.Trim()
+	if len(p) == 0 {
+		panic("zero polynomial has infinitely many roots")
+	}
+
+	var res []Root
+	zeros := 0
+	for p[zeros] == 0 {
+		zeros++
+	}
+	if zeros > 0 {
+		res = append(res, Root{Multiplicity: zeros})
+		p = p[zeros:]
+	}
+	if len(p) == 1 {
+		return res, nil
+	}
+
+	approx, err := p.aberth()
+	if err != nil {
+		return nil, err
+	}
+	res = append(res, p.clusterRoots(approx)...)
+	sort.Slice(res, func(i, j int) bool {
+		if real(res[i].Value) != real(res[j].Value) {
+			return real(res[i].Value) < real(res[j].Value)
+		}
+		return imag(res[i].Value) < imag(res[j].Value)
+	})
+	return res, nil
+}
+
+// aberth approximates every root of p, which must have
+// a non-zero constant term.
+func (p Polynomial) aberth() ([]complex128, error) {
+	n := len(p) - 1
+	roots := make([]complex128, n)
+
+	// Start on a circle whose radius is the geometric
+	// mean of the root magnitudes, with an offset angle
+	// to break symmetry for real polynomials.
+	radius := math.Pow(math.Abs(p[0]/p[n]), 1/float64(n))
+	for i := range roots {
+		angle := 2*math.Pi*float64(i)/float64(n) + 0.4
+		roots[i] = cmplx.Rect(radius, angle)
+	}
+
+	converged := make([]bool, n)
+	for iter := 0; iter < aberthMaxIters; iter++ {
+		done := true
+		for i, z := range roots {
+			if converged[i] {
+				continue
+			}
+			value, deriv, bound := p.evalComplex(z)
+			if cmplx.Abs(value) <= bound {
+				converged[i] = true
+				continue
+			}
+			done = false
+			ratio := value / deriv
+			var repulsion complex128
+			for j, other := range roots {
+				if j != i {
+					repulsion += 1 / (z - other)
+				}
+			}
+			step := ratio / (1 - ratio*repulsion)
+			if cmplx.IsNaN(step) || cmplx.IsInf(step) {
+				continue
+			}
+			roots[i] = z - step
+			if roots[i] == z {
+				converged[i] = true
+			}
+		}
+		if done {
+			return roots, nil
+		}
+	}
+	return nil, ErrNoConvergence
+}
+
+// clusterRoots merges approximate roots whose
+// inclusion discs overlap.
+//
+// Each approximation z_i has a disc of radius
+// n*|p(z_i)| / |a_n * prod_{j!=i}(z_i - z_j)|, and a
+// connected union of m of these discs contains exactly
+// m roots of p.
+func (p Polynomial) clusterRoots(approx []complex128) []Root {
+	n := len(approx)
+	leading := complex(p[len(p)-1], 0)
+	radii := make([]float64, n)
+	for i, z := range approx {
+		value, _, bound := p.evalComplex(z)
+		denom := leading
+		for j, other := range approx {
+			if j != i {
+				denom *= z - other
+			}
+		}
+		radii[i] = float64(n) * (cmplx.Abs(value) + bound) / cmplx.Abs(denom)
+		if math.IsNaN(radii[i]) {
+			radii[i] = math.Inf(1)
+		}
+	}
+
+	// Union-find over overlapping discs.
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range approx {
+		for j := i + 1; j < n; j++ {
+			if cmplx.Abs(approx[i]-approx[j]) <= radii[i]+radii[j] {
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+
+	clusters := map[int][]int{}
+	var order []int
+	for i := range approx {
+		root := find(i)
+		if _, ok := clusters[root]; !ok {
+			order = append(order, root)
+		}
+		clusters[root] = append(clusters[root], i)
+	}
+
+	var res []Root
+	for _, key := range order {
+		members := clusters[key]
+		var center complex128
+		for _, i := range members {
+			center += approx[i]
+		}
+		center /= complex(float64(len(members)), 0)
+		var bound float64
+		for _, i := range members {
+			bound = math.Max(bound, cmplx.Abs(approx[i]-center)+radii[i])
+		}
+		// Snap roots which are within their error bound
+		// of the real axis onto it, widening the bound
+		// to match.
+		if math.Abs(imag(center)) <= bound {
+			bound += math.Abs(imag(center))
+			center = complex(real(center), 0)
+		}
+		res = append(res, Root{
+			Value:        center,
+			Multiplicity: len(members),
+			ErrorBound:   bound,
+		})
+	}
+	return res
+}
+
+// evalComplex evaluates p and its derivative at z,
+// along with a bound on the rounding error in p(z).
+func (p Polynomial) evalComplex(z complex128) (value, deriv complex128, bound float64) {
+	absZ := cmplx.Abs(z)
+	var absSum float64
+	for i := len(p) - 1; i >= 0; i-- {
+		deriv = deriv*z + value
+		value = value*z + complex(p[i], 0)
+		absSum = absSum*absZ + math.Abs(p[i])
+	}
+	unitRoundoff := (math.Nextafter(1, 2) - 1) / 2
+	bound = 4 * float64(len(p)) * unitRoundoff * absSum
+	return
+}
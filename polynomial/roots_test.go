@@ -0,0 +1,118 @@
+package polynomial
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestRootsSimple(t *testing.T) {
+	// (x-1)(x+2)(x^2+1)
+	p := Polynomial{-1, 1}.Mul(Polynomial{2, 1}).Mul(Polynomial{1, 0, 1})
+	roots, err := p.Roots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []complex128{-2, -1i, 1i, 1}
+	verifyRoots(t, roots, expected, []int{1, 1, 1, 1})
+}
+
+func TestRootsMultiple(t *testing.T) {
+	// x^2 * (x-1)^3 * (x+2)^2 * (x-3)
+	p := Polynomial{0, 0, 1}
+	for i := 0; i < 3; i++ {
+		p = p.Mul(Polynomial{-1, 1})
+	}
+	p = p.Mul(Polynomial{4, 4, 1}).Mul(Polynomial{-3, 1})
+	roots, err := p.Roots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyRoots(t, roots, []complex128{-2, 0, 1, 3}, []int{2, 2, 3, 1})
+}
+
+func TestRootsHighDegree(t *testing.T) {
+	// The roots of x^n - 1 are the roots of unity.
+	n := 40
+	p := make(Polynomial, n+1)
+	p[0] = -1
+	p[n] = 1
+	roots, err := p.Roots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) != n {
+		t.Fatal("expected", n, "roots but got", len(roots))
+	}
+	for _, r := range roots {
+		if r.Multiplicity != 1 {
+			t.Fatal("unexpected multiplicity:", r)
+		}
+		if math.Abs(cmplx.Abs(r.Value)-1) > 1e-12 || r.ErrorBound > 1e-10 {
+			t.Fatal("bad root:", r)
+		}
+	}
+}
+
+func TestSturmRoots(t *testing.T) {
+	// (x-1)^2 * (x+2) * (x-3)^3 * (x^2+1)
+	p := Polynomial{1, -2, 1}.Mul(Polynomial{2, 1}).Mul(Polynomial{1, 0, 1})
+	for i := 0; i < 3; i++ {
+		p = p.Mul(Polynomial{-3, 1})
+	}
+	roots := p.SturmRoots(1e-10)
+	expected := []float64{-2, 1, 3}
+	multiplicities := []int{1, 2, 3}
+	if len(roots) != len(expected) {
+		t.Fatal("unexpected roots:", roots)
+	}
+	for i, r := range roots {
+		if math.Abs(r.Value-expected[i]) > 1e-8 || r.ErrorBound > 1e-10 {
+			t.Error("root", i, "should be", expected[i], "but got", r)
+		}
+		if r.Multiplicity != multiplicities[i] {
+			t.Error("root", i, "should have multiplicity", multiplicities[i], "but got", r)
+		}
+	}
+
+	if count := p.CountRealRoots(-10, 10); count != 3 {
+		t.Error("expected 3 distinct roots but counted", count)
+	}
+	if count := p.CountRealRoots(0, 2); count != 1 {
+		t.Error("expected 1 root in (0, 2] but counted", count)
+	}
+}
+
+func TestSturmRootsEven(t *testing.T) {
+	// (x-0.5)^2 * (x^2+1) never changes sign.
+	p := Polynomial{-0.5, 1}.Mul(Polynomial{-0.5, 1}).Mul(Polynomial{1, 0, 1})
+	roots := p.SturmRoots(1e-12)
+	if len(roots) != 1 || math.Abs(roots[0].Value-0.5) > 1e-10 || roots[0].Multiplicity != 2 {
+		t.Error("unexpected roots:", roots)
+	}
+}
+
+func verifyRoots(t *testing.T, roots []Root, expected []complex128, mults []int) {
+	if len(roots) != len(expected) {
+		t.Fatal("expected", expected, "but got", roots)
+	}
+	for i, x := range expected {
+		// Roots with nearly equal real parts may be in
+		// either order, so match each one to the closest.
+		var r Root
+		for _, root := range roots {
+			if cmplx.Abs(root.Value-x) < cmplx.Abs(r.Value-x) || r.Multiplicity == 0 {
+				r = root
+			}
+		}
+		if cmplx.Abs(r.Value-x) > 1e-5 {
+			t.Error("root", i, "should be", x, "but got", r.Value)
+		}
+		if r.Multiplicity != mults[i] {
+			t.Error("root", i, "should have multiplicity", mults[i], "but got", r.Multiplicity)
+		}
+		if cmplx.Abs(r.Value-x) > r.ErrorBound && r.ErrorBound != 0 {
+			t.Error("root", i, "is outside of its error bound:", r)
+		}
+	}
+}
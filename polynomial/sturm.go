@@ -0,0 +1,195 @@
+package polynomial
+
+import (
+	"math"
+	"sort"
+)
+
+// squarefreeTolerance is the relative tolerance used
+// for the GCD computations in a squarefree
+// factorization.
+const squarefreeTolerance = 1e-10
+
+// A RealRoot is a real root of a polynomial.
+type RealRoot struct {
+	Value        float64
+	Multiplicity int
+
+	// ErrorBound is the half-width of an interval
+	// around Value which is known to contain the root.
+	ErrorBound float64
+}
+
+// SturmSequence computes the Sturm sequence of p,
+// starting with p and its derivative.
+// Each subsequent entry is the negated remainder of
+// dividing the two before it.
+func (p Polynomial) SturmSequence() []Polynomial {
+	p = p.Trim()
+	res := []Polynomial{p}
+	next := p.Derivative()
+	for len(next) > 0 {
+		res = append(res, next)
+		_, r := res[len(res)-2].DivMod(next)
+		next = r.Scale(-1)
+	}
+	return res
+}
+
+// CountRealRoots uses Sturm's theorem to count the
+// distinct real roots of p in the interval (a, b].
+//
+// Multiple roots are removed from p before the Sturm
+// sequence is computed, since rounding errors would
+// otherwise spoil the sequence.
+func (p Polynomial) CountRealRoots(a, b float64) int {
+	seq := p.squarefreePart().SturmSequence()
+	return signChanges(seq, a) - signChanges(seq, b)
+}
+
+// SturmRoots finds all of the real roots of p along
+// with their multiplicities, including the roots of
+// even multiplicity which do not change the sign of p.
+//
+// The polynomial is split into squarefree factors,
+// one for each multiplicity, and the roots of every
+// factor are isolated using Sturm sequences and then
+// refined by bisection until each one is known to
+// within prec.
+// The roots are sorted in ascending order.
+//
+// Finding multiplicities is numerically delicate, so
+// this works best for polynomials whose coefficients
+// are exact, such as those with integer coefficients.
+func (p Polynomial) SturmRoots(prec float64) []RealRoot {
+	p = p.Trim()
+	if len(p) == 0 {
+		panic("zero polynomial has infinitely many roots")
+	}
+	var res []RealRoot
+	for i, factor := range p.squarefreeFactors() {
+		for _, root := range factor.isolateRoots(prec) {
+			root.Multiplicity = i + 1
+			res = append(res, root)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Value < res[j].Value
+	})
+	return res
+}
+
+// squarefreeFactors uses Yun's algorithm to write p as
+// f_1 * f_2^2 * f_3^3 * ..., where each f_i is
+// squarefree and the factors are pairwise coprime.
+func (p Polynomial) squarefreeFactors() []Polynomial {
+	deriv := p.Derivative()
+	if len(deriv) == 0 {
+		return nil
+	}
+	a := p.GCD(deriv, squarefreeTolerance)
+	b := p.squarefreePart()
+	c, _ := deriv.DivMod(a)
+	d := c.Sub(b.Derivative())
+
+	var res []Polynomial
+	for b.Degree() > 0 {
+		a = b.GCD(d, squarefreeTolerance)
+		res = append(res, a)
+		b, _ = b.DivMod(a)
+		c, _ = d.DivMod(a)
+		d = c.Sub(b.Derivative())
+	}
+	return res
+}
+
+// squarefreePart divides p by its GCD with its
+// derivative, leaving a polynomial with the same roots
+// but no multiple roots.
+func (p Polynomial) squarefreePart() Polynomial {
+	deriv := p.Derivative()
+	if len(deriv) == 0 {
+		return p.Trim()
+	}
+	res, _ := p.DivMod(p.GCD(deriv, squarefreeTolerance))
+	return res
+}
+
+// isolateRoots finds the real roots of a squarefree
+// polynomial.
+func (p Polynomial) isolateRoots(prec float64) []RealRoot {
+	if p.Degree() < 1 {
+		return nil
+	}
+	seq := p.SturmSequence()
+	bound := p.cauchyBound()
+
+	var res []RealRoot
+	var isolate func(a, b float64, count int)
+	isolate = func(a, b float64, count int) {
+		mid := (a + b) / 2
+		if count == 0 {
+			return
+		} else if count == 1 || mid == a || mid == b {
+			res = append(res, p.refineRoot(a, b, prec))
+			return
+		}
+		leftCount := signChanges(seq, a) - signChanges(seq, mid)
+		isolate(a, mid, leftCount)
+		isolate(mid, b, count-leftCount)
+	}
+	isolate(-bound, bound, signChanges(seq, -bound)-signChanges(seq, bound))
+	return res
+}
+
+// refineRoot narrows down the only root of p in the
+// interval (a, b].
+func (p Polynomial) refineRoot(a, b, prec float64) RealRoot {
+	fb := p.Eval(b)
+	for {
+		if fb == 0 {
+			return RealRoot{Value: b}
+		}
+		mid := (a + b) / 2
+		if (b-a)/2 <= prec || mid == a || mid == b {
+			return RealRoot{Value: mid, ErrorBound: (b - a) / 2}
+		}
+		fm := p.Eval(mid)
+		if fm == 0 {
+			return RealRoot{Value: mid}
+		} else if (fm > 0) == (fb > 0) {
+			b, fb = mid, fm
+		} else {
+			a = mid
+		}
+	}
+}
+
+// cauchyBound returns a number which is strictly
+// greater than the magnitude of every root of p.
+func (p Polynomial) cauchyBound() float64 {
+	p = p.Trim()
+	leading := math.Abs(p[len(p)-1])
+	var maxRatio float64
+	for _, x := range p[:len(p)-1] {
+		maxRatio = math.Max(maxRatio, math.Abs(x)/leading)
+	}
+	return 1 + maxRatio
+}
+
+func signChanges(seq []Polynomial, x float64) int {
+	var count int
+	var lastSign float64
+	for _, poly := range seq {
+		val := poly.Eval(x)
+		if val == 0 {
+			continue
+		}
+		sign := math.Copysign(1, val)
+		if lastSign != 0 && sign != lastSign {
+			count++
+		}
+		lastSign = sign
+	}
+	return count
+}
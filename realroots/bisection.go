@@ -83,8 +83,8 @@ func (b *bisector) Step() {
 
 	if val == 0 {
 		b.done = true
-		b.interval.Start = val
-		b.interval.End = val
+		b.interval.Start = x
+		b.interval.End = x
 		return
 	}
 
@@ -0,0 +1,55 @@
+package realroots
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNoBracket is returned when a bracketing interval
+// could not be found.
+var ErrNoBracket = errors.New("no sign change found")
+
+// bracketGrowth is the factor by which ExpandBracket
+// grows an interval at each step.
+const bracketGrowth = 1.6
+
+// ExpandBracket grows the interval i outward until f
+// has opposite signs (or a zero) at its endpoints.
+// At each step, the endpoint with the smaller value of
+// |f| is pushed further out.
+//
+// If no bracket is found within maxIters steps, or if
+// f takes on a non-finite value, the last interval is
+// returned with ErrNoBracket.
+func ExpandBracket(f Func, i Interval, maxIters int) (Interval, error) {
+	a, b := i.Start, i.End
+	if a == b {
+		panic("interval must have non-zero length")
+	} else if a > b {
+		a, b = b, a
+	}
+	fa, fb := f.Eval(a), f.Eval(b)
+	for iter := 0; ; iter++ {
+		if !isFinite(fa) || !isFinite(fb) {
+			break
+		}
+		if fa == 0 || fb == 0 || (fa < 0) != (fb < 0) {
+			return Interval{a, b}, nil
+		}
+		if iter == maxIters {
+			break
+		}
+		if math.Abs(fa) < math.Abs(fb) {
+			a += bracketGrowth * (a - b)
+			fa = f.Eval(a)
+		} else {
+			b += bracketGrowth * (b - a)
+			fb = f.Eval(b)
+		}
+	}
+	return Interval{a, b}, ErrNoBracket
+}
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
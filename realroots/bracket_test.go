@@ -0,0 +1,38 @@
+package realroots
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpandBracket(t *testing.T) {
+	f := &countedFunc{f: func(x float64) float64 {
+		return math.Exp(x) - 1000
+	}}
+	i, err := ExpandBracket(f, Interval{0, 1}, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.Start > math.Log(1000) || i.End < math.Log(1000) {
+		t.Error("bad bracket", i)
+	}
+
+	i, err = ExpandBracket(sineFunction{}, Interval{4, 3.5}, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Sin(i.Start)*math.Sin(i.End) > 0 {
+		t.Error("bad bracket", i)
+	}
+
+	square := &countedFunc{f: func(x float64) float64 {
+		return x*x + 1
+	}}
+	_, err = ExpandBracket(square, Interval{-1, 1}, 20)
+	if err != ErrNoBracket {
+		t.Error("expected ErrNoBracket but got", err)
+	}
+	if square.count != 22 {
+		t.Error("unexpected call count", square.count)
+	}
+}
@@ -0,0 +1,101 @@
+package realroots
+
+import "math"
+
+const brentMaxIters = 1000
+
+// Brent finds a root of f on the interval i using
+// Brent's method, which combines inverse quadratic
+// interpolation and the secant method with bisection.
+// f must have opposite signs at i.Start and i.End.
+//
+// Like bisection, it always converges, but it usually
+// needs far fewer function evaluations.
+// The returned root is within prec of an actual root,
+// unless better precision is impossible using floating
+// points.
+func Brent(f Func, i Interval, prec float64) *Result {
+	counter := &countingFunc{f: f}
+	a, b := i.Start, i.End
+	fa, fb := counter.Eval(a), counter.Eval(b)
+	res := &Result{Bracket: i}
+	if fa == 0 || fb == 0 {
+		if fa == 0 {
+			b = a
+		}
+		res.Root = b
+		res.Bracket = Interval{b, b}
+		res.Evals = counter.count
+		res.Converged = true
+		return res
+	}
+	if (fa < 0) == (fb < 0) {
+		panic("interval does not bracket a root")
+	}
+
+	epsilon := math.Nextafter(1, 2) - 1
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for res.Iterations = 0; res.Iterations < brentMaxIters; res.Iterations++ {
+		if (fb < 0) == (fc < 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol := 2*epsilon*math.Abs(b) + prec/2
+		mid := (c - b) / 2
+		if math.Abs(mid) <= tol || fb == 0 {
+			res.Converged = true
+			break
+		}
+
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// Attempt interpolation.
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * mid * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*mid*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*mid*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = mid
+				e = d
+			}
+		} else {
+			d = mid
+			e = d
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, mid)
+		}
+		fb = counter.Eval(b)
+	}
+
+	res.Root = b
+	res.Bracket = sortedInterval(b, c)
+	res.Evals = counter.count
+	return res
+}
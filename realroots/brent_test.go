@@ -0,0 +1,67 @@
+package realroots
+
+import (
+	"math"
+	"testing"
+)
+
+type countedFunc struct {
+	f     func(x float64) float64
+	count int
+}
+
+func (c *countedFunc) Eval(x float64) float64 {
+	c.count++
+	return c.f(x)
+}
+
+func TestBrent(t *testing.T) {
+	p := Polynomial{75.8782, -45.3416, -15.3639, 5.29236}
+	res := Brent(p, Interval{4, 7}, 1e-12)
+	if !res.Converged {
+		t.Error("expected convergence")
+	}
+	if math.Abs(res.Root-4.136298660) > 1e-8 {
+		t.Error("expected", 4.136298660, "but got", res.Root)
+	}
+	if res.Bracket.Start > res.Root || res.Bracket.End < res.Root {
+		t.Error("bracket", res.Bracket, "does not contain", res.Root)
+	}
+
+	res = Brent(sineFunction{}, Interval{4, 1}, 1e-12)
+	if math.Abs(res.Root-math.Pi) > 1e-12 {
+		t.Error("expected", math.Pi, "but got", res.Root)
+	}
+
+	res = Brent(p, Interval{-2, 2}, 1e-5)
+	if res.Root != 2 && math.Abs(p.Eval(res.Root)) > 1e-3 {
+		t.Error("bad root", res.Root)
+	}
+}
+
+func TestBrentEvals(t *testing.T) {
+	f := &countedFunc{f: func(x float64) float64 {
+		return math.Exp(x) - 3
+	}}
+	res := Brent(f, Interval{0, 5}, 1e-12)
+	if math.Abs(res.Root-math.Log(3)) > 1e-12 {
+		t.Error("expected", math.Log(3), "but got", res.Root)
+	}
+	if res.Evals != f.count {
+		t.Error("expected", f.count, "evals but got", res.Evals)
+	}
+	if steps := bisectionSteps(Interval{0, 5}, 1e-12); res.Evals >= steps {
+		t.Error("expected fewer than", steps, "evals but got", res.Evals)
+	}
+}
+
+func TestBrentBadDekker(t *testing.T) {
+	bad := &badDekker{}
+	res := Brent(bad, Interval{0, 61}, 1e-7)
+	if math.Abs(res.Root-60) > 1e-6 {
+		t.Error("expected root of ~60 but got", res.Root)
+	}
+	if bad.callCount > 200 {
+		t.Error("unexpected call count", bad.callCount)
+	}
+}
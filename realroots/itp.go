@@ -0,0 +1,97 @@
+package realroots
+
+import "math"
+
+// ITP finds a root of f on the interval i using the
+// Interpolate, Truncate, and Project method.
+// f must have opposite signs at i.Start and i.End.
+//
+// ITP never needs more than one evaluation beyond what
+// bisection would need to reach the precision prec,
+// yet it converges superlinearly for well-behaved
+// functions.
+// If prec is smaller than the floating point spacing
+// at the ends of i (including if prec <= 0), that
+// spacing is used instead.
+func ITP(f Func, i Interval, prec float64) *Result {
+	counter := &countingFunc{f: f}
+	a, b := i.Start, i.End
+	if a > b {
+		a, b = b, a
+	}
+	ya, yb := counter.Eval(a), counter.Eval(b)
+	res := &Result{}
+	if ya == 0 || yb == 0 {
+		if ya == 0 {
+			b = a
+		}
+		res.Root = b
+		res.Bracket = Interval{b, b}
+		res.Evals = counter.count
+		res.Converged = true
+		return res
+	}
+	if (ya < 0) == (yb < 0) {
+		panic("interval does not bracket a root")
+	}
+
+	// Flip the function so that it increases from a to b.
+	sign := 1.0
+	if ya > 0 {
+		sign = -1
+		ya, yb = -ya, -yb
+	}
+
+	mag := math.Max(math.Abs(a), math.Abs(b))
+	if spacing := math.Nextafter(mag, math.Inf(1)) - mag; !(prec >= spacing) {
+		prec = spacing
+	}
+
+	const n0 = 1
+	k1 := 0.2 / (b - a)
+	const k2 = 2.0
+	nHalf := math.Ceil(math.Log2((b - a) / (2 * prec)))
+	nMax := math.Max(nHalf, 0) + n0
+
+	for b-a > 2*prec && float64(res.Iterations) < nMax {
+		mid := (a + b) / 2
+		if mid == a || mid == b {
+			break
+		}
+		radius := prec*math.Pow(2, nMax-float64(res.Iterations)) - (b-a)/2
+
+		// Interpolate, then truncate towards the midpoint,
+		// then project into the minmax interval.
+		falsePos := (yb*a - ya*b) / (yb - ya)
+		direction := math.Copysign(1, mid-falsePos)
+		delta := k1 * math.Pow(b-a, k2)
+		var truncated float64
+		if delta <= math.Abs(mid-falsePos) {
+			truncated = falsePos + direction*delta
+		} else {
+			truncated = mid
+		}
+		var x float64
+		if math.Abs(truncated-mid) <= radius {
+			x = truncated
+		} else {
+			x = mid - direction*radius
+		}
+
+		res.Iterations++
+		y := sign * counter.Eval(x)
+		if y > 0 {
+			b, yb = x, y
+		} else if y < 0 {
+			a, ya = x, y
+		} else {
+			a, b = x, x
+		}
+	}
+
+	res.Root = (a + b) / 2
+	res.Bracket = Interval{a, b}
+	res.Evals = counter.count
+	res.Converged = b-a <= 2*prec || (a+b)/2 == a || (a+b)/2 == b
+	return res
+}
@@ -0,0 +1,66 @@
+package realroots
+
+import (
+	"math"
+	"testing"
+)
+
+func TestITP(t *testing.T) {
+	p := Polynomial{75.8782, -45.3416, -15.3639, 5.29236}
+	res := ITP(p, Interval{4, 7}, 1e-12)
+	if !res.Converged {
+		t.Error("expected convergence")
+	}
+	if math.Abs(res.Root-4.136298660) > 1e-8 {
+		t.Error("expected", 4.136298660, "but got", res.Root)
+	}
+	if res.Bracket.End-res.Bracket.Start > 2e-12 {
+		t.Error("bracket too wide:", res.Bracket)
+	}
+
+	res = ITP(sineFunction{}, Interval{4, 1}, 1e-12)
+	if math.Abs(res.Root-math.Pi) > 1e-12 {
+		t.Error("expected", math.Pi, "but got", res.Root)
+	}
+}
+
+func TestITPEvals(t *testing.T) {
+	f := &countedFunc{f: func(x float64) float64 {
+		return x*x*x - x - 2
+	}}
+	res := ITP(f, Interval{1, 2}, 1e-12)
+	if res.Evals != f.count {
+		t.Error("expected", f.count, "evals but got", res.Evals)
+	}
+	if math.Abs(f.f(res.Root)) > 1e-10 {
+		t.Error("bad root", res.Root)
+	}
+	if steps := bisectionSteps(Interval{1, 2}, 1e-12); res.Iterations >= steps {
+		t.Error("expected fewer than", steps, "iterations but got", res.Iterations)
+	}
+
+	// ITP should never do much worse than bisection.
+	bad := &badDekker{}
+	res = ITP(bad, Interval{0, 61}, 1e-7)
+	if math.Abs(res.Root-60) > 1e-6 {
+		t.Error("expected root of ~60 but got", res.Root)
+	}
+	if steps := bisectionSteps(Interval{0, 61}, 2e-7); res.Iterations > steps+1 {
+		t.Error("expected at most", steps+1, "iterations but got", res.Iterations)
+	}
+}
+
+func TestITPTinyPrec(t *testing.T) {
+	for _, prec := range []float64{0, -1, 1e-300} {
+		res := ITP(sineFunction{}, Interval{3, 4}, prec)
+		if !res.Converged {
+			t.Error("expected convergence for prec", prec)
+		}
+		if math.Abs(res.Root-math.Pi) > 1e-15 {
+			t.Error("expected", math.Pi, "but got", res.Root, "for prec", prec)
+		}
+		if res.Iterations > 70 {
+			t.Error("too many iterations for prec", prec, ":", res.Iterations)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package realroots
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/autodiff"
+)
+
+// A NumFunc is a function whose derivative can be
+// computed with autodiff.Num.
+type NumFunc func(x autodiff.Num) autodiff.Num
+
+// Newton finds a root of f using Newton's method,
+// starting at x0 and computing derivatives with
+// automatic differentiation.
+//
+// Iteration stops once a step is no larger than prec,
+// or after maxIters steps.
+// Newton's method converges quadratically near simple
+// roots, but it may diverge from a bad start.
+func Newton(f NumFunc, x0, prec float64, maxIters int) *Result {
+	return derivativeMethod(x0, prec, maxIters, func(x float64) float64 {
+		out := f(autodiff.NewNumVar(x, 1, 0))
+		return out.Value / out.Gradient[0]
+	})
+}
+
+// Halley finds a root of f using Halley's method, which
+// uses the second derivative of f to converge cubically
+// near simple roots.
+//
+// It is otherwise like Newton.
+func Halley(f autodiff.DeepFunc, x0, prec float64, maxIters int) *Result {
+	return derivativeMethod(x0, prec, maxIters, func(x float64) float64 {
+		out := f(autodiff.NewDeepNumVar(x, 2))
+		y, dy, ddy := out.Value, out.Deriv.Value, out.Deriv.Deriv.Value
+		return 2 * y * dy / (2*dy*dy - y*ddy)
+	})
+}
+
+// derivativeMethod runs an iteration x -> x - step(x),
+// where each step evaluates the function once.
+func derivativeMethod(x0, prec float64, maxIters int, step func(x float64) float64) *Result {
+	res := &Result{Root: x0, Bracket: Interval{x0, x0}}
+	x := x0
+	for res.Iterations < maxIters {
+		res.Iterations++
+		res.Evals++
+		delta := step(x)
+		if delta == 0 && !math.IsNaN(delta) {
+			res.Converged = true
+			break
+		}
+		next := x - delta
+		if math.IsNaN(next) || math.IsInf(next, 0) {
+			break
+		}
+		res.Bracket = sortedInterval(x, next)
+		x = next
+		res.Root = x
+		if math.Abs(delta) <= prec {
+			res.Converged = true
+			break
+		}
+	}
+	return res
+}
@@ -0,0 +1,58 @@
+package realroots
+
+import (
+	"math"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/autodiff"
+)
+
+func TestNewton(t *testing.T) {
+	f := func(x autodiff.Num) autodiff.Num {
+		return x.Mul(x).Sub(autodiff.NewNum(2, 1))
+	}
+	res := Newton(f, 1, 1e-12, 50)
+	if !res.Converged {
+		t.Error("expected convergence")
+	}
+	if math.Abs(res.Root-math.Sqrt2) > 1e-12 {
+		t.Error("expected", math.Sqrt2, "but got", res.Root)
+	}
+	if res.Iterations > 10 {
+		t.Error("too many iterations:", res.Iterations)
+	}
+
+	cos := func(x autodiff.Num) autodiff.Num {
+		return x.Cos()
+	}
+	res = Newton(cos, 0, 1e-12, 50)
+	if res.Converged {
+		t.Error("expected failure at a stationary point")
+	}
+}
+
+func TestHalley(t *testing.T) {
+	f := func(x *autodiff.DeepNum) *autodiff.DeepNum {
+		return x.Exp().AddScaler(-3)
+	}
+	res := Halley(f, 0, 1e-12, 50)
+	if !res.Converged {
+		t.Error("expected convergence")
+	}
+	if math.Abs(res.Root-math.Log(3)) > 1e-12 {
+		t.Error("expected", math.Log(3), "but got", res.Root)
+	}
+
+	newtonRes := Newton(func(x autodiff.Num) autodiff.Num {
+		return x.Exp().Sub(autodiff.NewNum(3, 1))
+	}, 0, 1e-12, 50)
+	if res.Iterations >= newtonRes.Iterations {
+		t.Error("expected Halley to take fewer than", newtonRes.Iterations,
+			"iterations but it took", res.Iterations)
+	}
+
+	res = Halley(f, 0, 0, 2)
+	if res.Converged || res.Iterations != 2 {
+		t.Error("unexpected result", res)
+	}
+}
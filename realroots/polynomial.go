@@ -164,7 +164,7 @@ func (p Polynomial) singleOddRoot() float64 {
 // endBehaviorRight returns an x value after
 // a given x at which the function is positive.
 func (p Polynomial) endBehaviorRight(x float64) float64 {
-	epsilon := math.Abs(math.Nextafter(x, x*2) - x)
+	epsilon := math.Nextafter(x, math.Inf(1)) - x
 	for p.Eval(x+epsilon) <= 0 {
 		epsilon *= 2
 	}
@@ -176,7 +176,7 @@ func (p Polynomial) endBehaviorRight(x float64) float64 {
 // negative or positive, depending on the
 // degree of p.
 func (p Polynomial) endBehaviorLeft(x float64) float64 {
-	epsilon := math.Abs(math.Nextafter(x, x*2) - x)
+	epsilon := math.Nextafter(x, math.Inf(1)) - x
 	if p.even() {
 		for p.Eval(x-epsilon) <= 0 {
 			epsilon *= 2
@@ -21,6 +21,13 @@ func TestPolynomialRootsFancy(t *testing.T) {
 	generalRootTest(t, poly, []float64{math.Log(2)}, 1e-4)
 }
 
+func TestPolynomialRootsExact(t *testing.T) {
+	// Roots which bisection hits exactly.
+	generalRootTest(t, Polynomial{1, -3, 2}, []float64{0.5, 1}, 1e-8)
+	// Roots which are symmetric about zero.
+	generalRootTest(t, Polynomial{-1, 0, 1}, []float64{-1, 1}, 1e-8)
+}
+
 func generalRootTest(t *testing.T, poly Polynomial, roots []float64, prec float64) {
 	actual := poly.OddRoots()
 	if len(actual) != len(roots) {
@@ -0,0 +1,41 @@
+package realroots
+
+// A Result describes the outcome of a root finder.
+type Result struct {
+	Root float64
+
+	// Iterations is the number of steps taken, and
+	// Evals is the number of times the function (and,
+	// for derivative-based methods, its derivatives)
+	// was evaluated.
+	Iterations int
+	Evals      int
+
+	// Bracket is the final interval known to contain the
+	// root for bracketing methods.
+	// For Newton and Halley, which do not bracket the
+	// root, it spans the last two iterates.
+	Bracket Interval
+
+	// Converged is false if the root finder gave up
+	// before reaching the requested precision.
+	Converged bool
+}
+
+// countingFunc wraps a Func to count evaluations.
+type countingFunc struct {
+	f     Func
+	count int
+}
+
+func (c *countingFunc) Eval(x float64) float64 {
+	c.count++
+	return c.f.Eval(x)
+}
+
+func sortedInterval(a, b float64) Interval {
+	if a > b {
+		return Interval{b, a}
+	}
+	return Interval{a, b}
+}
@@ -0,0 +1,117 @@
+package realroots
+
+import (
+	"math"
+	"sort"
+)
+
+// ScanRoots searches for all of the roots of f on the
+// interval i by sampling f at samples+1 evenly spaced
+// points.
+//
+// Every sign change between neighboring samples is
+// refined with Brent's method to precision prec.
+// Local minima of |f| at which f does not change sign
+// are searched more closely: if f crosses zero there,
+// both crossings are refined, and if |f| only gets
+// within touchTol of zero, the minimum is reported as
+// a root of even multiplicity.
+// Shallow crossings within touchTol are also treated
+// as a single root, since they are usually rounding
+// error near a double root.
+// Set touchTol to 0 to only look for sign changes.
+//
+// Roots closer together than the sample spacing may
+// be missed, and the result is sorted.
+func ScanRoots(f Func, i Interval, samples int, prec, touchTol float64) []float64 {
+	if samples < 1 {
+		panic("need at least one subinterval")
+	}
+	xs := make([]float64, samples+1)
+	ys := make([]float64, samples+1)
+	for k := range xs {
+		xs[k] = i.Start + (i.End-i.Start)*float64(k)/float64(samples)
+		ys[k] = f.Eval(xs[k])
+	}
+
+	var roots []float64
+	for k, y := range ys {
+		if y == 0 {
+			roots = append(roots, xs[k])
+		}
+	}
+	for k := 0; k < samples; k++ {
+		if ys[k] != 0 && ys[k+1] != 0 && (ys[k] < 0) != (ys[k+1] < 0) {
+			roots = append(roots, Brent(f, Interval{xs[k], xs[k+1]}, prec).Root)
+		}
+	}
+	for k := 1; k < samples; k++ {
+		y := ys[k]
+		if y == 0 || (ys[k-1] < 0) != (y < 0) || (ys[k+1] < 0) != (y < 0) ||
+			ys[k-1] == 0 || ys[k+1] == 0 {
+			continue
+		}
+		if math.Abs(y) > math.Abs(ys[k-1]) || math.Abs(y) > math.Abs(ys[k+1]) {
+			continue
+		}
+		roots = append(roots, touchingRoots(f, xs[k-1], xs[k+1], prec, touchTol)...)
+	}
+
+	sort.Float64s(roots)
+	return roots
+}
+
+// touchingRoots looks for roots near a minimum of |f|
+// on [a, b], where f has the same sign at a, b, and the
+// midpoint.
+func touchingRoots(f Func, a, b, prec, touchTol float64) []float64 {
+	sign := 1.0
+	if f.Eval((a+b)/2) < 0 {
+		sign = -1
+	}
+	x, y := goldenMinimize(func(x float64) float64 {
+		return sign * f.Eval(x)
+	}, a, b, prec)
+	if math.Abs(y) <= touchTol || y == 0 {
+		return []float64{x}
+	} else if y < 0 {
+		return []float64{
+			Brent(f, Interval{a, x}, prec).Root,
+			Brent(f, Interval{x, b}, prec).Root,
+		}
+	}
+	return nil
+}
+
+// goldenMinimize approximates a minimum of f on [a, b]
+// using golden-section search.
+// It stops early if it finds a non-positive value.
+func goldenMinimize(f func(x float64) float64, a, b, prec float64) (x, y float64) {
+	ratio := (math.Sqrt(5) - 1) / 2
+	x1 := b - ratio*(b-a)
+	x2 := a + ratio*(b-a)
+	y1, y2 := f(x1), f(x2)
+	for b-a > prec {
+		if y1 <= 0 {
+			return x1, y1
+		} else if y2 <= 0 {
+			return x2, y2
+		}
+		if y1 < y2 {
+			b, x2, y2 = x2, x1, y1
+			x1 = b - ratio*(b-a)
+			y1 = f(x1)
+		} else {
+			a, x1, y1 = x1, x2, y2
+			x2 = a + ratio*(b-a)
+			y2 = f(x2)
+		}
+		if x1 == x2 {
+			break
+		}
+	}
+	if y1 < y2 {
+		return x1, y1
+	}
+	return x2, y2
+}
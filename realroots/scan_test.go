@@ -0,0 +1,58 @@
+package realroots
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScanRoots(t *testing.T) {
+	roots := ScanRoots(sineFunction{}, Interval{-10, 10}, 100, 1e-12, 0)
+	if len(roots) != 7 {
+		t.Fatal("expected 7 roots but got", roots)
+	}
+	for i, root := range roots {
+		expected := float64(i-3) * math.Pi
+		if math.Abs(root-expected) > 1e-11 {
+			t.Error("root", i, "should be", expected, "but got", root)
+		}
+	}
+}
+
+func TestScanRootsTouching(t *testing.T) {
+	// (x-1)^2 (x+2) has a double root at 1.
+	p := Polynomial{2, -3, 0, 1}
+	roots := ScanRoots(p, Interval{-3.1, 3.3}, 20, 1e-10, 1e-12)
+	expected := []float64{-2, 1}
+	if len(roots) != len(expected) {
+		t.Fatal("expected", expected, "but got", roots)
+	}
+	for i, x := range expected {
+		if math.Abs(roots[i]-x) > 1e-5 {
+			t.Error("expected", x, "but got", roots[i])
+		}
+	}
+
+	// The local minimum at 1 is 1e-3 above zero.
+	p = Polynomial{2.001, -3, 0, 1}
+	roots = ScanRoots(p, Interval{-3.1, 3.3}, 20, 1e-10, 1e-4)
+	if len(roots) != 1 {
+		t.Error("expected only the simple root but got", roots)
+	}
+	roots = ScanRoots(p, Interval{-3.1, 3.3}, 20, 1e-10, 1e-2)
+	if len(roots) != 2 || math.Abs(roots[1]-1) > 1e-4 {
+		t.Error("expected near-root at 1 but got", roots)
+	}
+
+	// Two roots too close together for the samples.
+	p = Polynomial{1 - 1e-6, -2, 1}
+	roots = ScanRoots(p, Interval{-2.05, 4.05}, 10, 1e-12, 0)
+	expected = []float64{1 - 1e-3, 1 + 1e-3}
+	if len(roots) != len(expected) {
+		t.Fatal("expected", expected, "but got", roots)
+	}
+	for i, x := range expected {
+		if math.Abs(roots[i]-x) > 1e-9 {
+			t.Error("expected", x, "but got", roots[i])
+		}
+	}
+}
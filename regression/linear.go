@@ -0,0 +1,135 @@
+package regression
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/leastsquares"
+)
+
+// A LinearFit is the result of a multiple linear
+// regression, fitting outputs as a linear combination
+// of the columns of a design matrix.
+type LinearFit struct {
+	// Intercept is true if the model includes a constant
+	// term, in which case the first coefficient (and the
+	// first entry of every statistic) corresponds to it.
+	Intercept bool
+
+	Coefficients linalg.Vector
+	StdErrors    linalg.Vector
+	TStats       linalg.Vector
+
+	// Covariance is the estimated covariance matrix of
+	// the coefficients.
+	Covariance *linalg.Matrix
+
+	// Residuals stores the output minus the prediction
+	// for each row of the design matrix.
+	Residuals linalg.Vector
+
+	// ResidualVariance is the unbiased estimate of the
+	// noise variance, using DegreesOfFreedom.
+	ResidualVariance float64
+	DegreesOfFreedom int
+
+	RSquared    float64
+	AdjRSquared float64
+}
+
+// FitLinear performs ordinary least-squares regression
+// of outputs against the columns of design, which has
+// one row per observation.
+//
+// If intercept is true, a constant column is added in
+// front of the design matrix, and R^2 is measured
+// relative to the mean output.
+// Otherwise, R^2 is measured relative to zero.
+//
+// The problem is solved by QR decomposition, so the
+// design matrix (with the intercept) must have
+// independent columns.
+// For the statistics to be finite, there must be more
+// observations than coefficients.
+func FitLinear(design *linalg.Matrix, outputs linalg.Vector, intercept bool) *LinearFit {
+	if design.Rows != len(outputs) {
+		panic("dimension mismatch")
+	}
+	x := design
+	if intercept {
+		x = withIntercept(design)
+	}
+
+	solver := leastsquares.NewSolver(x)
+	coeffs := solver.Solve(outputs)
+	predictions := linalg.Vector(x.Mul(linalg.NewMatrixColumn(coeffs)).Data)
+	residuals := predictions.Scale(-1).Add(outputs)
+
+	n, p := x.Rows, x.Cols
+	dof := n - p
+	sse := residuals.Dot(residuals)
+	variance := sse / float64(dof)
+
+	cov := solver.NormalInverse().Scale(variance)
+	stdErrs := make(linalg.Vector, p)
+	tStats := make(linalg.Vector, p)
+	for i := range stdErrs {
+		stdErrs[i] = math.Sqrt(cov.Get(i, i))
+		tStats[i] = coeffs[i] / stdErrs[i]
+	}
+
+	var center float64
+	if intercept {
+		for _, y := range outputs {
+			center += y
+		}
+		center /= float64(n)
+	}
+	var sst float64
+	for _, y := range outputs {
+		sst += (y - center) * (y - center)
+	}
+	rSquared := 1 - sse/sst
+	totalDof := n
+	if intercept {
+		totalDof--
+	}
+	adjRSquared := 1 - (1-rSquared)*float64(totalDof)/float64(dof)
+
+	return &LinearFit{
+		Intercept:        intercept,
+		Coefficients:     coeffs,
+		StdErrors:        stdErrs,
+		TStats:           tStats,
+		Covariance:       cov,
+		Residuals:        residuals,
+		ResidualVariance: variance,
+		DegreesOfFreedom: dof,
+		RSquared:         rSquared,
+		AdjRSquared:      adjRSquared,
+	}
+}
+
+// Predict evaluates the fit for a row of inputs, which
+// should not include the intercept column.
+func (l *LinearFit) Predict(inputs linalg.Vector) float64 {
+	coeffs := l.Coefficients
+	var res float64
+	if l.Intercept {
+		res = coeffs[0]
+		coeffs = coeffs[1:]
+	}
+	if len(inputs) != len(coeffs) {
+		panic("dimension mismatch")
+	}
+	return res + coeffs.Dot(inputs)
+}
+
+func withIntercept(m *linalg.Matrix) *linalg.Matrix {
+	res := linalg.NewMatrix(m.Rows, m.Cols+1)
+	for i := 0; i < m.Rows; i++ {
+		res.Set(i, 0, 1)
+		copy(res.Data[i*res.Cols+1:(i+1)*res.Cols], m.Data[i*m.Cols:(i+1)*m.Cols])
+	}
+	return res
+}
@@ -0,0 +1,102 @@
+package regression
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestFitLinearSimple(t *testing.T) {
+	design := &linalg.Matrix{Rows: 5, Cols: 1, Data: []float64{1, 2, 3, 4, 5}}
+	outputs := linalg.Vector{2, 4, 5, 4, 5}
+	fit := FitLinear(design, outputs, true)
+
+	checks := []struct {
+		name     string
+		actual   float64
+		expected float64
+	}{
+		{"intercept", fit.Coefficients[0], 2.2},
+		{"slope", fit.Coefficients[1], 0.6},
+		{"intercept error", fit.StdErrors[0], math.Sqrt(0.88)},
+		{"slope error", fit.StdErrors[1], math.Sqrt(0.08)},
+		{"slope t-stat", fit.TStats[1], 0.6 / math.Sqrt(0.08)},
+		{"variance", fit.ResidualVariance, 0.8},
+		{"R^2", fit.RSquared, 0.6},
+		{"adjusted R^2", fit.AdjRSquared, 1 - 0.4*4/3},
+		{"covariance", fit.Covariance.Get(0, 1), -0.24},
+		{"prediction", fit.Predict(linalg.Vector{6}), 5.8},
+	}
+	for _, c := range checks {
+		if math.Abs(c.actual-c.expected) > 1e-8 {
+			t.Error("bad", c.name, "- expected", c.expected, "but got", c.actual)
+		}
+	}
+
+	expectedResiduals := linalg.Vector{-0.8, 0.6, 1, -0.6, -0.2}
+	for i, x := range expectedResiduals {
+		if math.Abs(fit.Residuals[i]-x) > 1e-8 {
+			t.Error("expected residuals", expectedResiduals, "but got", fit.Residuals)
+			break
+		}
+	}
+	if fit.DegreesOfFreedom != 3 {
+		t.Error("expected 3 degrees of freedom but got", fit.DegreesOfFreedom)
+	}
+}
+
+func TestFitLinearNoIntercept(t *testing.T) {
+	design := &linalg.Matrix{Rows: 3, Cols: 1, Data: []float64{1, 2, 3}}
+	outputs := linalg.Vector{1, 3, 2}
+	fit := FitLinear(design, outputs, false)
+
+	// The slope is sum(x*y)/sum(x^2) = 13/14, and R^2 is
+	// measured against the uncentered sum of squares.
+	slope := 13.0 / 14
+	var sse float64
+	for i, x := range design.Data {
+		sse += math.Pow(outputs[i]-slope*x, 2)
+	}
+	if math.Abs(fit.Coefficients[0]-slope) > 1e-8 {
+		t.Error("expected slope", slope, "but got", fit.Coefficients[0])
+	}
+	if expected := 1 - sse/14; math.Abs(fit.RSquared-expected) > 1e-8 {
+		t.Error("expected R^2", expected, "but got", fit.RSquared)
+	}
+	if expected := 1 - (sse/14)*3/2; math.Abs(fit.AdjRSquared-expected) > 1e-8 {
+		t.Error("expected adjusted R^2", expected, "but got", fit.AdjRSquared)
+	}
+}
+
+func TestFitLinearMultivariate(t *testing.T) {
+	gen := rand.New(rand.NewSource(1337))
+	design := linalg.NewMatrix(200, 3)
+	outputs := make(linalg.Vector, 200)
+	trueCoeffs := linalg.Vector{1.5, -2, 0, 3}
+	for i := 0; i < design.Rows; i++ {
+		outputs[i] = trueCoeffs[0] + gen.NormFloat64()*0.1
+		for j := 0; j < design.Cols; j++ {
+			x := gen.NormFloat64()
+			design.Set(i, j, x)
+			outputs[i] += x * trueCoeffs[j+1]
+		}
+	}
+	fit := FitLinear(design, outputs, true)
+
+	for i, x := range trueCoeffs {
+		if math.Abs(fit.Coefficients[i]-x) > 5*fit.StdErrors[i] {
+			t.Error("coefficient", i, "is", fit.Coefficients[i], "but expected", x)
+		}
+	}
+	if math.Abs(fit.TStats[1]) < 100 || math.Abs(fit.TStats[2]) > 5 {
+		t.Error("unexpected t-statistics:", fit.TStats)
+	}
+	if fit.RSquared < 0.99 || fit.AdjRSquared > fit.RSquared {
+		t.Error("unexpected R^2:", fit.RSquared, fit.AdjRSquared)
+	}
+	if math.Abs(fit.ResidualVariance-0.01) > 0.005 {
+		t.Error("unexpected residual variance:", fit.ResidualVariance)
+	}
+}
@@ -0,0 +1,267 @@
+package regression
+
+import (
+	"math"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+// A Criterion is a way of scoring polynomial fits of
+// different degrees against each other.
+// Lower scores are better.
+type Criterion int
+
+const (
+	// AIC is the Akaike information criterion.
+	AIC Criterion = iota
+
+	// BIC is the Bayesian information criterion, which
+	// penalizes extra coefficients more than AIC.
+	BIC
+
+	// CrossValidation is the mean squared error of
+	// leave-one-out cross-validation.
+	CrossValidation
+)
+
+// An OrthogonalFit is a least-squares polynomial fit
+// expressed in a basis of polynomials which are
+// orthogonal over the inputs of the data.
+//
+// Unlike FitPolynomial, which solves the normal
+// equations for the monomial coefficients, this
+// remains accurate for high degrees.
+type OrthogonalFit struct {
+	Degree int
+
+	// Coeffs stores the coefficient of each orthogonal
+	// basis polynomial.
+	Coeffs []float64
+
+	// Residuals stores the output minus the prediction
+	// for each point.
+	Residuals linalg.Vector
+
+	SSE              float64
+	ResidualVariance float64
+	RSquared         float64
+
+	// The scores are +Inf when there are no more points
+	// than coefficients, since an interpolating fit
+	// says nothing about how well the model generalizes.
+	// ResidualVariance is NaN in this case.
+	AIC             float64
+	BIC             float64
+	CrossValidation float64
+
+	// The basis is p_0 = 1, p_1 = x - alpha[0], and
+	// p_{k+1} = (x - alpha[k])*p_k - beta[k]*p_{k-1}.
+	alpha []float64
+	beta  []float64
+}
+
+// FitOrthogonal uses least-squares to find a polynomial
+// of the given degree to match the points.
+//
+// There must be at least deg+1 unique Input values.
+func FitOrthogonal(deg int, points []Point) *OrthogonalFit {
+	return fitOrthogonalDegrees(deg, points)[deg]
+}
+
+// SelectDegree fits polynomials of every degree up to
+// maxDeg and returns the fit with the best score under
+// the criterion.
+// The scores for each degree are returned as well.
+// A fit with as many coefficients as points scores
+// +Inf, so it is never selected over a smaller fit.
+//
+// There must be at least maxDeg+1 unique Input values.
+func SelectDegree(maxDeg int, points []Point, c Criterion) (*OrthogonalFit, []float64) {
+	fits := fitOrthogonalDegrees(maxDeg, points)
+	scores := make([]float64, len(fits))
+	best := 0
+	for i, fit := range fits {
+		switch c {
+		case AIC:
+			scores[i] = fit.AIC
+		case BIC:
+			scores[i] = fit.BIC
+		case CrossValidation:
+			scores[i] = fit.CrossValidation
+		default:
+			panic("unknown criterion")
+		}
+		if scores[i] < scores[best] {
+			best = i
+		}
+	}
+	return fits[best], scores
+}
+
+// Eval evaluates the fit at the given input.
+func (o *OrthogonalFit) Eval(x float64) float64 {
+	var prev float64
+	cur := 1.0
+	res := o.Coeffs[0]
+	for k := 0; k < o.Degree; k++ {
+		next := (x-o.alpha[k])*cur - o.beta[k]*prev
+		prev, cur = cur, next
+		res += o.Coeffs[k+1] * cur
+	}
+	return res
+}
+
+// Polynomial converts the fit to a Polynomial in the
+// monomial basis.
+//
+// For high degrees, the monomial coefficients may be
+// much less accurate than the fit itself, so Eval
+// should be preferred for evaluation.
+func (o *OrthogonalFit) Polynomial() Polynomial {
+	res := make(Polynomial, o.Degree+1)
+	prev := Polynomial{}
+	cur := Polynomial{1}
+	res[0] = o.Coeffs[0]
+	for k := 0; k < o.Degree; k++ {
+		next := make(Polynomial, k+2)
+		for i, c := range cur {
+			next[i+1] += c
+			next[i] -= o.alpha[k] * c
+		}
+		for i, c := range prev {
+			next[i] -= o.beta[k] * c
+		}
+		prev, cur = cur, next
+		for i, c := range cur {
+			res[i] += o.Coeffs[k+1] * c
+		}
+	}
+	return res
+}
+
+// fitOrthogonalDegrees fits every degree up to maxDeg,
+// sharing one set of discrete orthogonal polynomials
+// between all of the fits.
+func fitOrthogonalDegrees(maxDeg int, points []Point) []*OrthogonalFit {
+	n := len(points)
+	if maxDeg < 0 {
+		panic("degree must be non-negative")
+	}
+	if maxDeg+1 > n {
+		panic("not enough points")
+	}
+	inputs := make(linalg.Vector, n)
+	outputs := make(linalg.Vector, n)
+	var mean float64
+	for i, p := range points {
+		inputs[i] = p.Input
+		outputs[i] = p.Output
+		mean += p.Output / float64(n)
+	}
+	var sst float64
+	for _, y := range outputs {
+		sst += (y - mean) * (y - mean)
+	}
+
+	basis := []linalg.Vector{make(linalg.Vector, n)}
+	for i := range basis[0] {
+		basis[0][i] = 1
+	}
+	norms := []float64{float64(n)}
+	var alpha, beta []float64
+
+	residuals := outputs.Copy()
+	leverage := make(linalg.Vector, n)
+	coeffs := make([]float64, 0, maxDeg+1)
+	fits := make([]*OrthogonalFit, 0, maxDeg+1)
+	for deg := 0; deg <= maxDeg; deg++ {
+		if deg > 0 {
+			p := basis[deg-1]
+			var a float64
+			for i, x := range inputs {
+				a += x * p[i] * p[i]
+			}
+			a /= norms[deg-1]
+			var b float64
+			if deg > 1 {
+				b = norms[deg-1] / norms[deg-2]
+			}
+			next := make(linalg.Vector, n)
+			var scale float64
+			for i, x := range inputs {
+				next[i] = (x - a) * p[i]
+				if deg > 1 {
+					next[i] -= b * basis[deg-2][i]
+				}
+				scale += x * x * p[i] * p[i]
+			}
+
+			// If there are only deg unique inputs, then the
+			// new polynomial is zero up to rounding error.
+			norm := next.Dot(next)
+			if !(norm > 1e-20*scale) {
+				panic("not enough unique inputs")
+			}
+			alpha = append(alpha, a)
+			beta = append(beta, b)
+			basis = append(basis, next)
+			norms = append(norms, norm)
+		}
+
+		p := basis[deg]
+		c := residuals.Dot(p) / norms[deg]
+		coeffs = append(coeffs, c)
+		residuals.Add(p.Copy().Scale(-c))
+		for i, x := range p {
+			leverage[i] += x * x / norms[deg]
+		}
+
+		fits = append(fits, newOrthogonalFit(deg, coeffs, alpha, beta, residuals,
+			leverage, sst))
+	}
+	return fits
+}
+
+func newOrthogonalFit(deg int, coeffs, alpha, beta []float64, residuals,
+	leverage linalg.Vector, sst float64) *OrthogonalFit {
+	n := float64(len(residuals))
+	k := float64(deg + 1)
+	sse := residuals.Dot(residuals)
+
+	if k >= n {
+		inf := math.Inf(1)
+		return &OrthogonalFit{
+			Degree:           deg,
+			Coeffs:           append([]float64{}, coeffs...),
+			Residuals:        residuals.Copy(),
+			SSE:              sse,
+			ResidualVariance: math.NaN(),
+			RSquared:         1 - sse/sst,
+			AIC:              inf,
+			BIC:              inf,
+			CrossValidation:  inf,
+			alpha:            append([]float64{}, alpha...),
+			beta:             append([]float64{}, beta...),
+		}
+	}
+
+	var cv float64
+	for i, r := range residuals {
+		loo := r / (1 - leverage[i])
+		cv += loo * loo / n
+	}
+
+	return &OrthogonalFit{
+		Degree:           deg,
+		Coeffs:           append([]float64{}, coeffs...),
+		Residuals:        residuals.Copy(),
+		SSE:              sse,
+		ResidualVariance: sse / (n - k),
+		RSquared:         1 - sse/sst,
+		AIC:              n*math.Log(sse/n) + 2*k,
+		BIC:              n*math.Log(sse/n) + k*math.Log(n),
+		CrossValidation:  cv,
+		alpha:            append([]float64{}, alpha...),
+		beta:             append([]float64{}, beta...),
+	}
+}
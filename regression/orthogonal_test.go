@@ -0,0 +1,143 @@
+package regression
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFitOrthogonalExact(t *testing.T) {
+	points := []Point{
+		Point{-2, 1},
+		Point{0, 1},
+		Point{2, 25},
+		Point{1, 7},
+	}
+	fit := FitOrthogonal(3, points)
+	poly := fit.Polynomial()
+	expected := Polynomial{1, 2, 3, 1}
+	for i, x := range poly {
+		if math.Abs(x-expected[i]) > 1e-8 {
+			t.Errorf("bad x^%d term; got %f expected %f", i, x, expected[i])
+		}
+	}
+	for _, p := range points {
+		if math.Abs(fit.Eval(p.Input)-p.Output) > 1e-8 {
+			t.Error("bad value at", p.Input, "- got", fit.Eval(p.Input))
+		}
+	}
+	if fit.SSE > 1e-16 {
+		t.Error("expected exact fit but got SSE", fit.SSE)
+	}
+}
+
+func TestFitOrthogonalHighDegree(t *testing.T) {
+	var points []Point
+	for i := 0; i < 100; i++ {
+		x := 10 + float64(i)/10
+		points = append(points, Point{x, math.Sin(x)})
+	}
+	fit := FitOrthogonal(20, points)
+	for _, p := range points {
+		if math.Abs(fit.Eval(p.Input)-p.Output) > 1e-9 {
+			t.Fatal("bad value at", p.Input, "- got", fit.Eval(p.Input), "expected", p.Output)
+		}
+	}
+	if math.Abs(fit.RSquared-1) > 1e-12 {
+		t.Error("unexpected R^2:", fit.RSquared)
+	}
+}
+
+func TestFitOrthogonalStatistics(t *testing.T) {
+	gen := rand.New(rand.NewSource(1337))
+	var points []Point
+	for i := 0; i < 20; i++ {
+		x := gen.Float64()*4 - 2
+		points = append(points, Point{x, 1 - x + gen.NormFloat64()})
+	}
+	fit := FitOrthogonal(2, points)
+
+	var sse float64
+	for i, p := range points {
+		residual := p.Output - fit.Eval(p.Input)
+		if math.Abs(residual-fit.Residuals[i]) > 1e-8 {
+			t.Error("bad residual", i, "- got", fit.Residuals[i], "expected", residual)
+		}
+		sse += residual * residual
+	}
+	if math.Abs(sse-fit.SSE) > 1e-8 {
+		t.Error("expected SSE", sse, "but got", fit.SSE)
+	}
+	if math.Abs(fit.ResidualVariance-sse/17) > 1e-8 {
+		t.Error("bad residual variance:", fit.ResidualVariance)
+	}
+
+	// Compare to explicit leave-one-out fits.
+	var cv float64
+	for i, p := range points {
+		others := append(append([]Point{}, points[:i]...), points[i+1:]...)
+		diff := p.Output - FitOrthogonal(2, others).Eval(p.Input)
+		cv += diff * diff / float64(len(points))
+	}
+	if math.Abs(cv-fit.CrossValidation) > 1e-8 {
+		t.Error("expected CV score", cv, "but got", fit.CrossValidation)
+	}
+}
+
+func TestSelectDegree(t *testing.T) {
+	gen := rand.New(rand.NewSource(1337))
+	var points []Point
+	for i := 0; i < 60; i++ {
+		x := gen.Float64()*4 - 2
+		y := Polynomial{1, -2, 0, 1.5}.Eval(x) + gen.NormFloat64()*0.3
+		points = append(points, Point{x, y})
+	}
+	for _, c := range []Criterion{AIC, BIC, CrossValidation} {
+		fit, scores := SelectDegree(10, points, c)
+		if len(scores) != 11 {
+			t.Fatal("expected 11 scores but got", len(scores))
+		}
+		if fit.Degree < 3 || fit.Degree > 5 {
+			t.Error("criterion", c, "chose degree", fit.Degree)
+		}
+		for _, s := range scores {
+			if s < scores[fit.Degree] {
+				t.Error("criterion", c, "did not choose the best score")
+			}
+		}
+	}
+	if fit, _ := SelectDegree(10, points, BIC); fit.Degree != 3 {
+		t.Error("BIC chose degree", fit.Degree)
+	}
+}
+
+func TestSelectDegreeSaturated(t *testing.T) {
+	gen := rand.New(rand.NewSource(42))
+	var points []Point
+	for i := 0; i < 8; i++ {
+		x := float64(i)
+		points = append(points, Point{x, 2*x + 1 + gen.NormFloat64()*0.1})
+	}
+	for _, c := range []Criterion{AIC, BIC, CrossValidation} {
+		fit, scores := SelectDegree(len(points)-1, points, c)
+		if !math.IsInf(scores[len(points)-1], 1) {
+			t.Error("criterion", c, "gave interpolant score", scores[len(points)-1])
+		}
+		if fit.Degree > 2 {
+			t.Error("criterion", c, "chose degree", fit.Degree, "with scores", scores)
+		}
+	}
+	if fit := FitOrthogonal(len(points)-1, points); !math.IsNaN(fit.ResidualVariance) {
+		t.Error("expected NaN residual variance but got", fit.ResidualVariance)
+	}
+}
+
+func TestFitOrthogonalDuplicateInputs(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	points := []Point{{1, 2}, {1, 3}, {2, 1}, {2, 4}}
+	FitOrthogonal(2, points)
+}
@@ -14,9 +14,14 @@ type Polynomial []float64
 // FitPolynomial uses least-squares to find a polynomial
 // to match the set of points as well as possible.
 //
-// If there are fewer points than deg?1, or if there are
+// If there are fewer points than deg+1, or if there are
 // fewer than deg+1 unique Input values, then this may
 // return an incorrect or invalid solution.
+//
+// This solves the normal equations for the monomial
+// coefficients, which become ill-conditioned quickly
+// as deg grows.
+// For high degrees, use FitOrthogonal instead.
 func FitPolynomial(deg int, points []Point) Polynomial {
 	outputs := make(linalg.Vector, len(points))
 	for row, p := range points {
@@ -27,7 +32,7 @@ func FitPolynomial(deg int, points []Point) Polynomial {
 	for d := range degInputs {
 		v := make(linalg.Vector, len(points))
 		for i, p := range points {
-			v[i] = math.Pow(p.Input, float64(i))
+			v[i] = math.Pow(p.Input, float64(d))
 		}
 		degInputs[d] = v
 	}
@@ -46,3 +51,12 @@ func FitPolynomial(deg int, points []Point) Polynomial {
 
 	return Polynomial(cholesky.Decompose(normalMat).Solve(normalOut))
 }
+
+// Eval evaluates the polynomial at the given input.
+func (p Polynomial) Eval(x float64) float64 {
+	var res float64
+	for i := len(p) - 1; i >= 0; i-- {
+		res = res*x + p[i]
+	}
+	return res
+}
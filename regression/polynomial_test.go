@@ -13,9 +13,12 @@ func TestFitPolynomial(t *testing.T) {
 		Point{1, 7},
 	}
 	poly := FitPolynomial(3, points)
-	expected := Polynomial{1, 3, 2, 1}
+	expected := Polynomial{1, 2, 3, 1}
+	if len(poly) != len(expected) {
+		t.Fatal("expected", len(expected), "coefficients but got", len(poly))
+	}
 	for i, x := range poly {
-		if math.Abs(x-expected[i]) > 0.0001 {
+		if !(math.Abs(x-expected[i]) <= 0.0001) {
 			t.Errorf("bad x^%d term; got %f expected %f", i, x, expected[i])
 		}
 	}
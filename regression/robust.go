@@ -0,0 +1,207 @@
+package regression
+
+import (
+	"math"
+	"sort"
+
+	"github.com/unixpickle/num-analysis/linalg"
+	"github.com/unixpickle/num-analysis/linalg/leastsquares"
+)
+
+const (
+	robustMaxIters  = 200
+	robustTolerance = 1e-10
+
+	// madScale converts the median absolute deviation of
+	// normally distributed data into a standard deviation.
+	madScale = 0.6744897501960817
+)
+
+// A Loss is a robust replacement for the squared error
+// in least-squares regression.
+type Loss interface {
+	// Weight returns the weight which iteratively
+	// reweighted least-squares should give a residual,
+	// measured in units of the estimated noise scale.
+	Weight(r float64) float64
+}
+
+// Huber is the Huber loss, which is quadratic for
+// residuals smaller than K and linear beyond that.
+//
+// If K is 0, the conventional value of 1.345 is used.
+type Huber struct {
+	K float64
+}
+
+func (h Huber) Weight(r float64) float64 {
+	k := h.K
+	if k == 0 {
+		k = 1.345
+	}
+	if math.Abs(r) <= k {
+		return 1
+	}
+	return k / math.Abs(r)
+}
+
+// Tukey is Tukey's bisquare loss, which ignores
+// residuals larger than C entirely.
+//
+// Since the loss is not convex, fits using it are
+// started from a Huber fit.
+// If C is 0, the conventional value of 4.685 is used.
+type Tukey struct {
+	C float64
+}
+
+func (t Tukey) Weight(r float64) float64 {
+	c := t.C
+	if c == 0 {
+		c = 4.685
+	}
+	if math.Abs(r) >= c {
+		return 0
+	}
+	x := r / c
+	return (1 - x*x) * (1 - x*x)
+}
+
+// L1 is the absolute error, which makes the fit a
+// least-absolute-deviation regression.
+type L1 struct{}
+
+func (L1) Weight(r float64) float64 {
+	return 1 / math.Max(math.Abs(r), 1e-8)
+}
+
+// A RobustFit is the result of a robust regression.
+type RobustFit struct {
+	// Coefficients stores the fit, starting with the
+	// intercept if there is one.
+	Coefficients linalg.Vector
+
+	// Weights stores the final weight of each point.
+	// Outliers have weights near zero, or exactly zero
+	// for Tukey's bisquare.
+	Weights linalg.Vector
+
+	// Residuals stores the output minus the prediction
+	// for each point.
+	Residuals linalg.Vector
+
+	// Scale is the estimated standard deviation of the
+	// noise, based on the median absolute residual.
+	Scale float64
+
+	Iterations int
+	Converged  bool
+}
+
+// FitRobust performs robust linear regression of
+// outputs against the columns of design using
+// iteratively reweighted least-squares.
+//
+// If intercept is true, a constant column is added in
+// front of the design matrix.
+// The design matrix must have independent columns, even
+// once the outliers are ignored.
+func FitRobust(design *linalg.Matrix, outputs linalg.Vector, intercept bool,
+	loss Loss) *RobustFit {
+	if design.Rows != len(outputs) {
+		panic("dimension mismatch")
+	}
+	x := design
+	if intercept {
+		x = withIntercept(design)
+	}
+
+	var coeffs linalg.Vector
+	switch loss.(type) {
+	case Tukey, *Tukey:
+		coeffs = fitIRLS(x, outputs, Huber{}, nil).Coefficients
+	default:
+		coeffs = leastsquares.NewSolver(x).Solve(outputs)
+	}
+	return fitIRLS(x, outputs, loss, coeffs)
+}
+
+// FitPolynomialRobust is like FitPolynomial, but it
+// uses a robust loss to limit the influence of
+// outliers.
+func FitPolynomialRobust(deg int, points []Point, loss Loss) (Polynomial, *RobustFit) {
+	design := linalg.NewMatrix(len(points), deg+1)
+	outputs := make(linalg.Vector, len(points))
+	for i, p := range points {
+		outputs[i] = p.Output
+		power := 1.0
+		for d := 0; d <= deg; d++ {
+			design.Set(i, d, power)
+			power *= p.Input
+		}
+	}
+	fit := FitRobust(design, outputs, false, loss)
+	return Polynomial(fit.Coefficients.Copy()), fit
+}
+
+func fitIRLS(x *linalg.Matrix, outputs linalg.Vector, loss Loss,
+	coeffs linalg.Vector) *RobustFit {
+	if coeffs == nil {
+		coeffs = leastsquares.NewSolver(x).Solve(outputs)
+	}
+	res := &RobustFit{Coefficients: coeffs}
+	for res.Iterations = 0; res.Iterations < robustMaxIters; res.Iterations++ {
+		res.computeResiduals(x, outputs)
+		res.computeWeights(loss)
+		if res.Scale == 0 {
+			res.Converged = true
+			return res
+		}
+
+		newCoeffs := leastsquares.NewWeightedSolver(x, res.Weights).Solve(outputs)
+		change := newCoeffs.Copy().Scale(-1).Add(res.Coefficients).MaxAbs()
+		res.Coefficients = newCoeffs
+		if change <= robustTolerance*math.Max(1, newCoeffs.MaxAbs()) {
+			res.Converged = true
+			res.Iterations++
+			break
+		}
+	}
+	res.computeResiduals(x, outputs)
+	res.computeWeights(loss)
+	return res
+}
+
+func (r *RobustFit) computeResiduals(x *linalg.Matrix, outputs linalg.Vector) {
+	predictions := linalg.Vector(x.Mul(linalg.NewMatrixColumn(r.Coefficients)).Data)
+	r.Residuals = predictions.Scale(-1).Add(outputs)
+
+	abs := make([]float64, len(r.Residuals))
+	for i, x := range r.Residuals {
+		abs[i] = math.Abs(x)
+	}
+	r.Scale = median(abs) / madScale
+}
+
+func (r *RobustFit) computeWeights(loss Loss) {
+	r.Weights = make(linalg.Vector, len(r.Residuals))
+	for i, x := range r.Residuals {
+		if r.Scale != 0 {
+			r.Weights[i] = loss.Weight(x / r.Scale)
+		} else if x == 0 {
+			// Most of the points are fit exactly, and any
+			// others are infinitely far outliers.
+			r.Weights[i] = 1
+		}
+	}
+}
+
+func median(list []float64) float64 {
+	sorted := append([]float64{}, list...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,119 @@
+package regression
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/unixpickle/num-analysis/linalg"
+)
+
+func TestFitPolynomialRobust(t *testing.T) {
+	gen := rand.New(rand.NewSource(1337))
+	var points []Point
+	outliers := map[int]bool{}
+	for i := 0; i < 50; i++ {
+		x := float64(i) / 10
+		y := 2 + 3*x + gen.NormFloat64()*0.05
+		if i%10 == 3 {
+			y += 50
+			outliers[i] = true
+		}
+		points = append(points, Point{x, y})
+	}
+
+	expected := Polynomial{2, 3}
+	if poly := FitPolynomial(1, points); math.Abs(poly[0]-expected[0]) < 1 {
+		t.Fatal("least-squares fit was unexpectedly accurate:", poly)
+	}
+
+	for _, loss := range []Loss{Huber{}, Tukey{}, L1{}} {
+		poly, fit := FitPolynomialRobust(1, points, loss)
+		for i, x := range expected {
+			if math.Abs(poly[i]-x) > 0.1 {
+				t.Errorf("%T: expected %v but got %v", loss, expected, poly)
+				break
+			}
+		}
+		if math.Abs(fit.Scale-0.05) > 0.03 {
+			t.Errorf("%T: unexpected scale %f", loss, fit.Scale)
+		}
+		for i, w := range fit.Weights {
+			if outliers[i] && w > 0.05*fit.Weights[0] {
+				t.Errorf("%T: outlier %d has weight %f", loss, i, w)
+			}
+		}
+	}
+
+	_, fit := FitPolynomialRobust(1, points, Tukey{})
+	if !fit.Converged {
+		t.Error("Tukey fit did not converge")
+	}
+	for i, w := range fit.Weights {
+		if outliers[i] != (w == 0) {
+			t.Error("unexpected Tukey weight", w, "for point", i)
+		}
+	}
+
+	// A pointer loss should get the same Huber start.
+	_, ptrFit := FitPolynomialRobust(1, points, &Tukey{})
+	for i, x := range fit.Coefficients {
+		if ptrFit.Coefficients[i] != x {
+			t.Error("expected", fit.Coefficients, "but got", ptrFit.Coefficients)
+			break
+		}
+	}
+}
+
+func TestFitRobustDesign(t *testing.T) {
+	gen := rand.New(rand.NewSource(1337))
+	design := linalg.NewMatrix(100, 2)
+	outputs := make(linalg.Vector, 100)
+	for i := 0; i < design.Rows; i++ {
+		x1, x2 := gen.NormFloat64(), gen.NormFloat64()
+		design.Set(i, 0, x1)
+		design.Set(i, 1, x2)
+		outputs[i] = 1 + 2*x1 - x2 + gen.NormFloat64()*0.1
+		if i%7 == 0 {
+			outputs[i] -= 20
+		}
+	}
+	fit := FitRobust(design, outputs, true, Huber{})
+	expected := linalg.Vector{1, 2, -1}
+	for i, x := range expected {
+		if math.Abs(fit.Coefficients[i]-x) > 0.1 {
+			t.Fatal("expected", expected, "but got", fit.Coefficients)
+		}
+	}
+	if !fit.Converged {
+		t.Error("fit did not converge")
+	}
+}
+
+func TestFitRobustMedian(t *testing.T) {
+	// With only an intercept, the L1 fit is the median.
+	outputs := linalg.Vector{1, 7, 2, 100, 3}
+	design := linalg.NewMatrix(5, 0)
+	fit := FitRobust(design, outputs, true, L1{})
+	if math.Abs(fit.Coefficients[0]-3) > 1e-4 {
+		t.Error("expected 3 but got", fit.Coefficients[0])
+	}
+}
+
+func TestFitRobustExact(t *testing.T) {
+	// The fit only becomes exact after a few iterations,
+	// so the final weights must be recomputed for a
+	// zero scale.
+	outputs := linalg.Vector{0, 0, 0, 0, 0, 100, -50}
+	design := linalg.NewMatrix(len(outputs), 0)
+	fit := FitRobust(design, outputs, true, Huber{})
+	if fit.Scale != 0 {
+		t.Fatal("expected zero scale but got", fit.Scale)
+	}
+	expected := linalg.Vector{1, 1, 1, 1, 1, 0, 0}
+	for i, x := range expected {
+		if fit.Weights[i] != x {
+			t.Fatal("expected weights", expected, "but got", fit.Weights)
+		}
+	}
+}